@@ -0,0 +1,168 @@
+package finding
+
+// clone returns deep copy of RequestStandard
+func (sr RequestStandard) clone() RequestStandard {
+	if sr.Affiliate != nil {
+		affiliate := *sr.Affiliate
+		sr.Affiliate = &affiliate
+	}
+	return sr
+}
+
+// clone returns deep copy of RequestAspectFilter
+func (sr RequestAspectFilter) clone() RequestAspectFilter {
+	if sr.AspectFilter == nil {
+		return sr
+	}
+	aspectFilter := make([]ServiceAspectFilter, len(sr.AspectFilter))
+	for i, af := range sr.AspectFilter {
+		aspectFilter[i] = ServiceAspectFilter{
+			AspectName:      af.AspectName,
+			AspectValueName: cloneStrings(af.AspectValueName),
+		}
+	}
+	sr.AspectFilter = aspectFilter
+	return sr
+}
+
+// clone returns deep copy of RequestCategories
+func (sr RequestCategories) clone() RequestCategories {
+	sr.CategoryID = cloneStrings(sr.CategoryID)
+	return sr
+}
+
+// clone returns deep copy of RequestOutputSelector
+func (sr RequestOutputSelector) clone() RequestOutputSelector {
+	sr.OutputSelector = cloneStrings(sr.OutputSelector)
+	return sr
+}
+
+// clone returns deep copy of RequestItemFilter
+func (sr RequestItemFilter) clone() RequestItemFilter {
+	if sr.ItemFilterMap != nil {
+		itemFilterMap := make(map[ItemFilterParameter]ServiceItemFilter, len(sr.ItemFilterMap))
+		for k, v := range sr.ItemFilterMap {
+			itemFilterMap[k] = v.clone()
+		}
+		sr.ItemFilterMap = itemFilterMap
+	}
+	if sr.ItemFilter != nil {
+		itemFilter := make([]ServiceItemFilter, len(sr.ItemFilter))
+		for i, v := range sr.ItemFilter {
+			itemFilter[i] = v.clone()
+		}
+		sr.ItemFilter = itemFilter
+	}
+	return sr
+}
+
+// clone returns deep copy of ServiceItemFilter
+func (sif ServiceItemFilter) clone() ServiceItemFilter {
+	sif.Value = cloneStrings(sif.Value)
+	return sif
+}
+
+// cloneStrings copies slice keeping nil slice nil
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	c := make([]string, len(s))
+	copy(c, s)
+	return c
+}
+
+/*
+==============================================================================
+*/
+
+// Clone returns deep copy of AdvancedRequest.
+// The copy is bound to the same Service and can be changed and executed independently.
+func (sr *AdvancedRequest) Clone() *AdvancedRequest {
+	c := *sr
+	c.RequestCategories = sr.RequestCategories.clone()
+	c.RequestAspectFilter = sr.RequestAspectFilter.clone()
+	c.RequestItemFilter = sr.RequestItemFilter.clone()
+	c.RequestOutputSelector = sr.RequestOutputSelector.clone()
+	c.RequestStandard = sr.RequestStandard.clone()
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of ByCategoryRequest (see AdvancedRequest.Clone)
+func (sr *ByCategoryRequest) Clone() *ByCategoryRequest {
+	c := *sr
+	c.RequestCategories = sr.RequestCategories.clone()
+	c.RequestAspectFilter = sr.RequestAspectFilter.clone()
+	c.RequestItemFilter = sr.RequestItemFilter.clone()
+	c.RequestOutputSelector = sr.RequestOutputSelector.clone()
+	c.RequestStandard = sr.RequestStandard.clone()
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of ByKeywordsRequest (see AdvancedRequest.Clone)
+func (sr *ByKeywordsRequest) Clone() *ByKeywordsRequest {
+	c := *sr
+	c.RequestAspectFilter = sr.RequestAspectFilter.clone()
+	c.RequestItemFilter = sr.RequestItemFilter.clone()
+	c.RequestOutputSelector = sr.RequestOutputSelector.clone()
+	c.RequestStandard = sr.RequestStandard.clone()
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of ByProductRequest (see AdvancedRequest.Clone)
+func (sr *ByProductRequest) Clone() *ByProductRequest {
+	c := *sr
+	c.RequestItemFilter = sr.RequestItemFilter.clone()
+	c.RequestOutputSelector = sr.RequestOutputSelector.clone()
+	c.RequestStandard = sr.RequestStandard.clone()
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of InEbayStoresRequest (see AdvancedRequest.Clone)
+func (sr *InEbayStoresRequest) Clone() *InEbayStoresRequest {
+	c := *sr
+	c.RequestAspectFilter = sr.RequestAspectFilter.clone()
+	c.RequestCategories = sr.RequestCategories.clone()
+	c.RequestItemFilter = sr.RequestItemFilter.clone()
+	c.RequestOutputSelector = sr.RequestOutputSelector.clone()
+	c.RequestStandard = sr.RequestStandard.clone()
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of CompletedItemsRequest (see AdvancedRequest.Clone)
+func (sr *CompletedItemsRequest) Clone() *CompletedItemsRequest {
+	c := *sr
+	c.RequestCategories = sr.RequestCategories.clone()
+	c.RequestAspectFilter = sr.RequestAspectFilter.clone()
+	c.RequestItemFilter = sr.RequestItemFilter.clone()
+	c.RequestOutputSelector = sr.RequestOutputSelector.clone()
+	c.RequestStandard = sr.RequestStandard.clone()
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of GetHistogramsRequest (see AdvancedRequest.Clone)
+func (sr *GetHistogramsRequest) Clone() *GetHistogramsRequest {
+	c := *sr
+	c.RequestBasic.inputs = sr.RequestBasic.inputs.clone()
+	return &c
+}
+
+// Clone returns deep copy of GetKeywordsRecommendationRequest (see AdvancedRequest.Clone)
+func (sr *GetKeywordsRecommendationRequest) Clone() *GetKeywordsRecommendationRequest {
+	c := *sr
+	c.bindInputs(sr.RequestBasic.inputs.clone())
+	return &c
+}
+
+// Clone returns deep copy of GetVersionRequest (see AdvancedRequest.Clone)
+func (sr *GetVersionRequest) Clone() *GetVersionRequest {
+	c := *sr
+	c.RequestBasic.inputs = sr.RequestBasic.inputs.clone()
+	return &c
+}
@@ -0,0 +1,59 @@
+package finding
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestAdvancedRequest_Clone(t *testing.T) {
+	s := NewService("").WithStrictMode(true)
+	r := s.NewAdvancedRequest()
+	r.WithCategoryID("1")
+	r.WithAspectFilter("Color", "Red")
+	r.WithItemFilterSeller("a")
+	r.WithOutputSelectors(OutputSelectorSellerInfo)
+	r.WithAffiliate("1", "2", "3")
+	r.WithPageLimit(0)
+	body, err := r.GetBody()
+	assert.NoError(t, err)
+
+	c := r.Clone()
+	assert.Equal(t, r, c)
+	c.WithCategoryID("2")
+	c.AspectFilter[0].AspectValueName[0] = "Blue"
+	c.WithItemFilterSeller("b")
+	c.RemoveItemFilter(ItemFilterSeller)
+	c.WithOutputSelectors(OutputSelectorStoreInfo)
+	c.Affiliate.NetworkID = "9"
+	c.WithKeywords("a")
+
+	got, err := r.GetBody()
+	assert.NoError(t, err)
+	assert.Equal(t, body, got)
+	assert.Len(t, r.Err().(*InputErrors).Errors, 1)
+	assert.Len(t, c.Err().(*InputErrors).Errors, 2)
+}
+
+func TestAdvancedRequest_ConcurrentGetPage(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 50, 5)
+	r := NewService("").WithEndpoint(ts.URL).WithPageLimit(5).NewAdvancedRequest()
+	r.WithItemFilterSeller("a")
+
+	var wg sync.WaitGroup
+	for page := 1; page <= 10; page++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := r.GetPageContext(context.Background(), page)
+			if assert.NoError(t, err) {
+				assert.Equal(t, page, res.PaginationOutput.PageNumber)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Zero(t, r.PaginationInput.PageNumber)
+	assert.Nil(t, r.ItemFilter)
+}
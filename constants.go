@@ -5,6 +5,12 @@ const EbayRequestDataFormat = "XML"
 const EbayResponseDataFormat = "XML"
 const EbayServiceName = "FindingService"
 const DefaultItemsPerPage = 100
+
+// MaxPages is the maximum page number which can be retrieved by the Finding API
+const MaxPages = 100
+
+// MaxEntries is the maximum number of items which can be retrieved by the Finding API for a single search
+const MaxEntries = 10000
 const EbayFindingNamespace = "http://www.ebay.com/marketplace/search/v1/services"
 
 type EbayEndpoint string
@@ -19,6 +25,7 @@ const (
 type EbayOperation string
 
 const (
+	OperationFindCompletedItems              EbayOperation = "findCompletedItems"
 	OperationFindItemsAdvanced               EbayOperation = "findItemsAdvanced"
 	OperationFindItemsByCategory             EbayOperation = "findItemsByCategory"
 	OperationFindItemsByKeywords             EbayOperation = "findItemsByKeywords"
@@ -29,6 +36,33 @@ const (
 	OperationGetVersion                      EbayOperation = "getVersion"
 )
 
+type AckValue string
+
+const (
+	AckSuccess        AckValue = "Success"
+	AckWarning        AckValue = "Warning"
+	AckFailure        AckValue = "Failure"
+	AckPartialFailure AckValue = "PartialFailure"
+)
+
+type ErrorCategory string
+
+const (
+	// ErrorCategoryApplication indicates an error caused by the application (e.g. invalid AppID or eBay-side processing issue)
+	ErrorCategoryApplication ErrorCategory = "Application"
+	// ErrorCategoryRequest indicates an error caused by invalid input in the request
+	ErrorCategoryRequest ErrorCategory = "Request"
+	// ErrorCategorySystem indicates an error on eBay side (e.g. database or server down)
+	ErrorCategorySystem ErrorCategory = "System"
+)
+
+type ErrorSeverity string
+
+const (
+	ErrorSeverityError   ErrorSeverity = "Error"
+	ErrorSeverityWarning ErrorSeverity = "Warning"
+)
+
 type GlobalID string
 
 const (
@@ -120,6 +154,54 @@ const (
 	ItemFilterWorldOfGoodOnly       ItemFilterParameter = "WorldOfGoodOnly"
 )
 
+// itemFilterValueLimits are item filters known to the package with the maximum number of their values
+var itemFilterValueLimits = map[ItemFilterParameter]int{
+	ItemFilterAuthorizedSellerOnly:  1,
+	ItemFilterAvailableTo:           1,
+	ItemFilterBestOfferOnly:         1,
+	ItemFilterCharityOnly:           1,
+	ItemFilterCondition:             14,
+	ItemFilterCurrency:              1,
+	ItemFilterEndTimeFrom:           1,
+	ItemFilterEndTimeTo:             1,
+	ItemFilterExcludeAutoPay:        1,
+	ItemFilterExcludeCategory:       25,
+	ItemFilterExcludeSeller:         100,
+	ItemFilterExpeditedShippingType: 1,
+	ItemFilterFeaturedOnly:          1,
+	ItemFilterFeedbackScoreMax:      1,
+	ItemFilterFeedbackScoreMin:      1,
+	ItemFilterFreeShippingOnly:      1,
+	ItemFilterGetItFastOnly:         1,
+	ItemFilterHideDuplicateItems:    1,
+	ItemFilterListedIn:              1,
+	ItemFilterListingType:           6,
+	ItemFilterLocalPickupOnly:       1,
+	ItemFilterLocalSearchOnly:       1,
+	ItemFilterLocatedIn:             25,
+	ItemFilterLotsOnly:              1,
+	ItemFilterMaxBids:               1,
+	ItemFilterMaxDistance:           1,
+	ItemFilterMaxHandlingTime:       1,
+	ItemFilterMaxPrice:              1,
+	ItemFilterMaxQuantity:           1,
+	ItemFilterMinBids:               1,
+	ItemFilterMinPrice:              1,
+	ItemFilterMinQuantity:           1,
+	ItemFilterModTimeFrom:           1,
+	ItemFilterOutletSellerOnly:      1,
+	ItemFilterPaymentMethod:         1,
+	ItemFilterReturnsAcceptedOnly:   1,
+	ItemFilterSeller:                100,
+	ItemFilterSellerBusinessType:    1,
+	ItemFilterSoldItemsOnly:         1,
+	ItemFilterStartTimeFrom:         1,
+	ItemFilterStartTimeTo:           1,
+	ItemFilterTopRatedSellerOnly:    1,
+	ItemFilterValueBoxInventory:     1,
+	ItemFilterWorldOfGoodOnly:       1,
+}
+
 type SortOrderParameter string
 
 const (
@@ -137,6 +219,36 @@ const (
 	SortOrderWatchCountDecreaseSort   SortOrderParameter = "WatchCountDecreaseSort"
 )
 
+// completedItemsSortOrders are sort orders supported by findCompletedItems
+var completedItemsSortOrders = map[SortOrderParameter]struct{}{
+	SortOrderBestMatch:                {},
+	SortOrderBidCountFewest:           {},
+	SortOrderBidCountMost:             {},
+	SortOrderCountryAscending:         {},
+	SortOrderCountryDescending:        {},
+	SortOrderCurrentPriceHighest:      {},
+	SortOrderDistanceNearest:          {},
+	SortOrderEndTimeSoonest:           {},
+	SortOrderPricePlusShippingHighest: {},
+	SortOrderPricePlusShippingLowest:  {},
+	SortOrderStartTimeNewest:          {},
+}
+
+type SellingStateOption string
+
+const (
+	// SellingStateActive means the listing is still live
+	SellingStateActive SellingStateOption = "Active"
+	// SellingStateCanceled means the listing has been canceled by either the seller or eBay
+	SellingStateCanceled SellingStateOption = "Canceled"
+	// SellingStateEnded means the listing has ended and eBay is in the process of processing it
+	SellingStateEnded SellingStateOption = "Ended"
+	// SellingStateEndedWithSales means the listing has ended with sales
+	SellingStateEndedWithSales SellingStateOption = "EndedWithSales"
+	// SellingStateEndedWithoutSales means the listing has ended without sales
+	SellingStateEndedWithoutSales SellingStateOption = "EndedWithoutSales"
+)
+
 type ItemFilterCurrencyIDOption string
 
 const (
@@ -0,0 +1,384 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"strconv"
+	"time"
+)
+
+// PartitionDimension is a dimension the crawler splits the search by
+type PartitionDimension int
+
+const (
+	// PartitionByPrice splits the price range (MinPrice and MaxPrice item filters) in halves
+	PartitionByPrice PartitionDimension = iota
+	// PartitionByStartTime splits the listing start time window (StartTimeFrom and StartTimeTo item filters) in halves
+	PartitionByStartTime
+	// PartitionByCategory splits the search by child categories from CategoryHistogram.
+	// CategoryHistogram contains up to 10 child categories with approximate counts,
+	// so items of unlisted child categories may be missed.
+	PartitionByCategory
+)
+
+// crawlEpoch is the default lower bound of listing start time
+var crawlEpoch = time.Date(1995, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+// CrawlOptions configures Crawl
+type CrawlOptions struct {
+	// Dimensions is the order in which dimensions are tried to split a slice exceeding the Finding API ceiling.
+	// The first dimension which can split the slice is used.
+	// Default: PartitionByPrice, PartitionByStartTime, PartitionByCategory.
+	Dimensions []PartitionDimension
+	// Workers is the number of workers fetching pages of a slice (see FetchAll). Default: 1.
+	Workers int
+	// StartTimeFrom is the lower bound of start time windows if the request has no StartTimeFrom item filter.
+	// Default: 1995-09-01.
+	StartTimeFrom time.Time
+	// OnSlice is called before the items of a slice are fetched
+	OnSlice func(CrawlSlice)
+}
+
+// CrawlSlice is a part of the search which fits under the Finding API ceiling
+type CrawlSlice struct {
+	// CategoryID is the categories of the slice. Empty if the search isn't restricted by categories.
+	CategoryID []string
+	// MinPrice and MaxPrice are the price band of the slice. MaxPrice is +Inf if the band is unbounded.
+	MinPrice float64
+	MaxPrice float64
+	// StartTimeFrom and StartTimeTo are the start time window of the slice
+	StartTimeFrom time.Time
+	StartTimeTo   time.Time
+	// TotalEntries is the number of items in the slice reported by the Finding API
+	TotalEntries int
+	// Depth is the number of splits made to get the slice
+	Depth int
+
+	priceSet bool
+	timeSet  bool
+}
+
+// histogramResponse is a response with category histograms
+type histogramResponse interface {
+	pagedResponse
+	categoryHistograms() CategoryHistogramContainer
+}
+
+// categoryHistograms returns category histograms of the response
+func (r ResponseCategoryHistogramContainer) categoryHistograms() CategoryHistogramContainer {
+	return r.CategoryHistogramContainer
+}
+
+// newCrawlSlice creates the root slice from the item filters and categories of the request
+func newCrawlSlice(categories RequestCategories, filter RequestItemFilter, opts CrawlOptions) (CrawlSlice, error) {
+	s := CrawlSlice{
+		CategoryID:    categories.CategoryID,
+		MaxPrice:      math.Inf(1),
+		StartTimeFrom: crawlEpoch,
+		StartTimeTo:   time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if !opts.StartTimeFrom.IsZero() {
+		s.StartTimeFrom = opts.StartTimeFrom.UTC()
+	}
+	if v, ok := filterValue(filter, ItemFilterMinPrice); ok {
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return s, fmt.Errorf("parsing MinPrice: %w", err)
+		}
+		s.MinPrice, s.priceSet = price, true
+	}
+	if v, ok := filterValue(filter, ItemFilterMaxPrice); ok {
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return s, fmt.Errorf("parsing MaxPrice: %w", err)
+		}
+		s.MaxPrice, s.priceSet = price, true
+	}
+	if v, ok := filterValue(filter, ItemFilterStartTimeFrom); ok {
+		t, err := FromEbayDateTime(v)
+		if err != nil {
+			return s, fmt.Errorf("parsing StartTimeFrom: %w", err)
+		}
+		s.StartTimeFrom, s.timeSet = t, true
+	}
+	if v, ok := filterValue(filter, ItemFilterStartTimeTo); ok {
+		t, err := FromEbayDateTime(v)
+		if err != nil {
+			return s, fmt.Errorf("parsing StartTimeTo: %w", err)
+		}
+		s.StartTimeTo, s.timeSet = t, true
+	}
+	return s, nil
+}
+
+// filterValue returns the first value of single-value ItemFilter
+func filterValue(filter RequestItemFilter, ifp ItemFilterParameter) (string, bool) {
+	f, ok := filter.ItemFilterMap[ifp]
+	if !ok || len(f.Value) == 0 {
+		return "", false
+	}
+	return f.Value[0], true
+}
+
+// apply restricts the request components to the slice
+func (s CrawlSlice) apply(categories *RequestCategories, filter *RequestItemFilter) {
+	categories.inputs.forget("categoryId")
+	categories.CategoryID = cloneStrings(s.CategoryID)
+	if filter.ItemFilterMap == nil {
+		filter.Initialize()
+	}
+	if s.priceSet {
+		// both price filters must have the same currency
+		paramName, paramValue := "", ""
+		for _, ifp := range []ItemFilterParameter{ItemFilterMinPrice, ItemFilterMaxPrice} {
+			if f, ok := filter.ItemFilterMap[ifp]; ok && f.ParamName != "" {
+				paramName, paramValue = f.ParamName, f.ParamValue
+			}
+		}
+		filter.updateIFValueWithParameter(ItemFilterMinPrice, paramName, paramValue, strconv.FormatFloat(s.MinPrice, 'f', 2, 64))
+		if math.IsInf(s.MaxPrice, 1) {
+			delete(filter.ItemFilterMap, ItemFilterMaxPrice)
+		} else {
+			filter.updateIFValueWithParameter(ItemFilterMaxPrice, paramName, paramValue, strconv.FormatFloat(s.MaxPrice, 'f', 2, 64))
+		}
+	}
+	if s.timeSet {
+		filter.updateIFValue(ItemFilterStartTimeFrom, ToEbayDateTime(s.StartTimeFrom))
+		filter.updateIFValue(ItemFilterStartTimeTo, ToEbayDateTime(s.StartTimeTo))
+	}
+}
+
+// split splits the slice by the first dimension which can split it.
+// It returns nil if no dimension can split the slice.
+func (s CrawlSlice) split(dims []PartitionDimension, histograms CategoryHistogramContainer) []CrawlSlice {
+	for _, dim := range dims {
+		var parts []CrawlSlice
+		switch dim {
+		case PartitionByPrice:
+			parts = s.splitByPrice()
+		case PartitionByStartTime:
+			parts = s.splitByStartTime()
+		case PartitionByCategory:
+			parts = s.splitByCategory(histograms)
+		}
+		if len(parts) > 0 {
+			for i := range parts {
+				parts[i].Depth = s.Depth + 1
+				parts[i].TotalEntries = 0
+			}
+			return parts
+		}
+	}
+	return nil
+}
+
+// splitByPrice splits the price band in halves with cent precision.
+// Unbounded band is split into the bounded band and the rest.
+func (s CrawlSlice) splitByPrice() []CrawlSlice {
+	lo := int64(math.Round(s.MinPrice * 100))
+	var mid int64
+	if math.IsInf(s.MaxPrice, 1) {
+		mid = lo*2 + 10000
+	} else {
+		hi := int64(math.Round(s.MaxPrice * 100))
+		if hi <= lo {
+			return nil
+		}
+		mid = lo + (hi-lo)/2
+	}
+	left, right := s, s
+	left.priceSet, right.priceSet = true, true
+	left.MaxPrice = float64(mid) / 100
+	right.MinPrice = float64(mid+1) / 100
+	return []CrawlSlice{left, right}
+}
+
+// splitByStartTime splits the start time window in halves with millisecond precision
+func (s CrawlSlice) splitByStartTime() []CrawlSlice {
+	window := s.StartTimeTo.Sub(s.StartTimeFrom)
+	if window < time.Millisecond {
+		return nil
+	}
+	mid := s.StartTimeFrom.Add(window / 2).Truncate(time.Millisecond)
+	left, right := s, s
+	left.timeSet, right.timeSet = true, true
+	left.StartTimeTo = mid
+	right.StartTimeFrom = mid.Add(time.Millisecond)
+	return []CrawlSlice{left, right}
+}
+
+// splitByCategory splits the slice by its categories or by the child categories from histograms
+func (s CrawlSlice) splitByCategory(histograms CategoryHistogramContainer) []CrawlSlice {
+	var ids []string
+	switch {
+	case len(s.CategoryID) > 1:
+		ids = s.CategoryID
+	case len(s.CategoryID) == 1:
+		parent := s.CategoryID[0]
+		children := histograms.CategoryHistograms
+		if ch, ok := histograms.Find(parent); ok {
+			children = ch.ChildCategoryHistograms
+		}
+		for _, ch := range children {
+			if ch.CategoryId != parent {
+				ids = append(ids, ch.CategoryId)
+			}
+		}
+	default:
+		for _, ch := range histograms.CategoryHistograms {
+			ids = append(ids, ch.CategoryId)
+		}
+	}
+	parts := make([]CrawlSlice, 0, len(ids))
+	for _, id := range ids {
+		part := s
+		part.CategoryID = []string{id}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// cachedFirstPage returns fetcher which returns first instead of fetching page 1
+func cachedFirstPage(fetch pageFetcher, first pagedResponse) pageFetcher {
+	return func(ctx context.Context, page int) (pagedResponse, error) {
+		if page == 1 {
+			return first, nil
+		}
+		return fetch(ctx, page)
+	}
+}
+
+// crawl splits root slice recursively until every slice fits under the Finding API ceiling
+// and yields unique items of all slices. newFetcher creates page fetcher of the request restricted to the slice.
+func crawl(ctx context.Context, root CrawlSlice, opts CrawlOptions, newFetcher func(s CrawlSlice) pageFetcher) iter.Seq2[Item, error] {
+	dims := opts.Dimensions
+	if len(dims) == 0 {
+		dims = []PartitionDimension{PartitionByPrice, PartitionByStartTime, PartitionByCategory}
+	}
+	return func(yield func(Item, error) bool) {
+		ctx := withValidationTime(ctx)
+		seen := make(map[string]struct{})
+		stack := []CrawlSlice{root}
+		for len(stack) > 0 {
+			s := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			fetch := newFetcher(s)
+			first, err := fetch(ctx, 1)
+			if err != nil {
+				yield(Item{}, fmt.Errorf("probing slice: %w", err))
+				return
+			}
+			pagination := first.pagination()
+			s.TotalEntries = pagination.TotalEntries
+			if pagination.TotalPages > MaxPages || pagination.TotalEntries > MaxEntries {
+				var histograms CategoryHistogramContainer
+				if hr, ok := first.(histogramResponse); ok {
+					histograms = hr.categoryHistograms()
+				}
+				if parts := s.split(dims, histograms); len(parts) > 0 {
+					// the first part is crawled first
+					for i := len(parts) - 1; i >= 0; i-- {
+						stack = append(stack, parts[i])
+					}
+					continue
+				}
+			}
+			if opts.OnSlice != nil {
+				opts.OnSlice(s)
+			}
+			items, err := fetchAll(ctx, opts.Workers, cachedFirstPage(fetch, first))
+			for _, item := range items {
+				if _, ok := seen[item.ItemID]; ok {
+					continue
+				}
+				seen[item.ItemID] = struct{}{}
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if err != nil {
+				var truncErr *TruncatedError
+				if !yield(Item{}, err) || !errors.As(err, &truncErr) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// hasCategoryHistogram checks if CategoryHistogram output selector is requested
+func (sr RequestOutputSelector) hasCategoryHistogram() bool {
+	for _, selector := range sr.OutputSelector {
+		if selector == string(OutputSelectorCategoryHistogram) {
+			return true
+		}
+	}
+	return false
+}
+
+// usesDimension checks if dim is one of the crawl dimensions
+func (opts CrawlOptions) usesDimension(dim PartitionDimension) bool {
+	if len(opts.Dimensions) == 0 {
+		return true
+	}
+	for _, d := range opts.Dimensions {
+		if d == dim {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+==============================================================================
+*/
+
+// Crawl retrieves all items of AdvancedRequest beyond the Finding API ceiling.
+// The search is split recursively (see CrawlOptions) until every slice fits under the ceiling,
+// then every slice is fetched and items are deduplicated by ItemID.
+// If a slice can't be split anymore, its retrieved items are followed by *TruncatedError and crawling goes on.
+// Other errors stop crawling. AdvancedRequest isn't modified.
+func (sr *AdvancedRequest) Crawl(ctx context.Context, opts CrawlOptions) iter.Seq2[Item, error] {
+	root, err := newCrawlSlice(sr.RequestCategories, sr.RequestItemFilter, opts)
+	if err != nil {
+		return func(yield func(Item, error) bool) {
+			yield(Item{}, err)
+		}
+	}
+	return crawl(ctx, root, opts, func(s CrawlSlice) pageFetcher {
+		c := sr.Clone()
+		s.apply(&c.RequestCategories, &c.RequestItemFilter)
+		if opts.usesDimension(PartitionByCategory) && !c.hasCategoryHistogram() {
+			c.WithOutputSelectors(OutputSelectorCategoryHistogram)
+		}
+		return func(ctx context.Context, page int) (pagedResponse, error) {
+			res, err := c.GetPageContext(ctx, page)
+			return res, err
+		}
+	})
+}
+
+// Crawl retrieves all items of ByCategoryRequest beyond the Finding API ceiling (see AdvancedRequest.Crawl)
+func (sr *ByCategoryRequest) Crawl(ctx context.Context, opts CrawlOptions) iter.Seq2[Item, error] {
+	root, err := newCrawlSlice(sr.RequestCategories, sr.RequestItemFilter, opts)
+	if err != nil {
+		return func(yield func(Item, error) bool) {
+			yield(Item{}, err)
+		}
+	}
+	return crawl(ctx, root, opts, func(s CrawlSlice) pageFetcher {
+		c := sr.Clone()
+		s.apply(&c.RequestCategories, &c.RequestItemFilter)
+		if opts.usesDimension(PartitionByCategory) && !c.hasCategoryHistogram() {
+			c.WithOutputSelectors(OutputSelectorCategoryHistogram)
+		}
+		return func(ctx context.Context, page int) (pagedResponse, error) {
+			res, err := c.GetPageContext(ctx, page)
+			return res, err
+		}
+	})
+}
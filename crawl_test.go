@@ -0,0 +1,114 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// collectCrawl collects unique item IDs and errors of crawl
+func collectCrawl(seq func(yield func(Item, error) bool)) (map[string]struct{}, []error) {
+	ids := make(map[string]struct{})
+	var errs []error
+	for item, err := range seq {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids[item.ItemID] = struct{}{}
+	}
+	return ids, errs
+}
+
+func TestAdvancedRequest_CrawlByPrice(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]crawlItem, 12000)
+	for i := range items {
+		items[i] = crawlItem{id: strconv.Itoa(i), category: "1", price: float64(i%500) + 0.99, startTime: start}
+	}
+	var calls int32
+	ts := newCrawlServer(t, &calls, items)
+	s := NewService("").WithEndpoint(ts.URL)
+	r := s.NewAdvancedRequest()
+	r.WithKeywords("harry potter")
+
+	var slices []CrawlSlice
+	ids, errs := collectCrawl(r.Crawl(context.Background(), CrawlOptions{
+		Workers: 4,
+		OnSlice: func(s CrawlSlice) { slices = append(slices, s) },
+	}))
+	assert.Empty(t, errs)
+	assert.Len(t, ids, len(items))
+	total := 0
+	for _, slice := range slices {
+		assert.LessOrEqual(t, slice.TotalEntries, MaxEntries)
+		total += slice.TotalEntries
+	}
+	assert.Equal(t, len(items), total)
+	assert.NotContains(t, r.ItemFilterMap, ItemFilterMinPrice)
+}
+
+func TestByCategoryRequest_CrawlByStartTime(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]crawlItem, 10500)
+	for i := range items {
+		items[i] = crawlItem{id: strconv.Itoa(i), category: "1", price: 9.99, startTime: start.Add(time.Duration(i) * time.Minute)}
+	}
+	var calls int32
+	ts := newCrawlServer(t, &calls, items)
+	s := NewService("").WithEndpoint(ts.URL)
+	r := s.NewByCategoryRequest()
+	r.WithCategoryID("1")
+	r.WithItemFilterMinPrice(9.99)
+	r.WithItemFilterMaxPrice(9.99)
+
+	ids, errs := collectCrawl(r.Crawl(context.Background(), CrawlOptions{Workers: 4, StartTimeFrom: start}))
+	assert.Empty(t, errs)
+	assert.Len(t, ids, len(items))
+}
+
+func TestByCategoryRequest_CrawlByCategory(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]crawlItem, 10500)
+	for i := range items {
+		items[i] = crawlItem{id: strconv.Itoa(i), category: "1" + strconv.Itoa(i%3), price: 9.99, startTime: start}
+	}
+	var calls int32
+	ts := newCrawlServer(t, &calls, items)
+	s := NewService("").WithEndpoint(ts.URL)
+	r := s.NewByCategoryRequest()
+	r.WithCategoryID("1")
+
+	var categories []string
+	ids, errs := collectCrawl(r.Crawl(context.Background(), CrawlOptions{
+		Dimensions: []PartitionDimension{PartitionByCategory},
+		OnSlice:    func(s CrawlSlice) { categories = append(categories, s.CategoryID...) },
+	}))
+	assert.Empty(t, errs)
+	assert.Len(t, ids, len(items))
+	assert.ElementsMatch(t, []string{"10", "11", "12"}, categories)
+}
+
+func TestAdvancedRequest_CrawlTruncated(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]crawlItem, 10100)
+	for i := range items {
+		items[i] = crawlItem{id: strconv.Itoa(i), category: "1", price: 9.99, startTime: start}
+	}
+	var calls int32
+	ts := newCrawlServer(t, &calls, items)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	ids, errs := collectCrawl(s.NewAdvancedRequest().Crawl(context.Background(), CrawlOptions{
+		Dimensions: []PartitionDimension{PartitionByPrice},
+		Workers:    4,
+	}))
+	assert.Len(t, ids, MaxEntries)
+	if assert.Len(t, errs, 1) {
+		var truncErr *TruncatedError
+		assert.True(t, errors.As(errs[0], &truncErr))
+	}
+}
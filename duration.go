@@ -0,0 +1,182 @@
+package finding
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseEbayDuration parses ISO 8601 duration PnYnMnWnDTnHnMnS (e.g. P2DT23H32M51S, PT1.5S, P1W).
+// Seconds can be fractional (. or , separator), W is 7 days and D is 24 hours. Leading - negates the duration.
+// Years and months have no fixed length: durations with non-zero years or months are rejected,
+// use ParseEbayDurationAt to count them from a reference time.
+func ParseEbayDuration(s string) (time.Duration, error) {
+	p, err := parseISODuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if p.years != 0 || p.months != 0 {
+		return 0, fmt.Errorf("invalid duration %q: years and months have no fixed length, use ParseEbayDurationAt", s)
+	}
+	return p.fixed, nil
+}
+
+// ParseEbayDurationAt parses ISO 8601 duration like ParseEbayDuration, but years and months are
+// counted from the reference time ref (e.g. the timestamp of the response for TimeLeft):
+// P1M at January 31 is the duration to March 3 (or 2 in leap years), as with time.Time.AddDate.
+func ParseEbayDurationAt(s string, ref time.Time) (time.Duration, error) {
+	p, err := parseISODuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if p.years == 0 && p.months == 0 {
+		return p.fixed, nil
+	}
+	years, months := p.years, p.months
+	if p.negative {
+		years, months = -years, -months
+	}
+	if years > math.MaxInt32 || years < math.MinInt32 || months > math.MaxInt32 || months < math.MinInt32 {
+		return 0, fmt.Errorf("invalid duration %q: out of range", s)
+	}
+	calendar := ref.AddDate(int(years), int(months), 0).Sub(ref)
+	d := calendar + p.fixed
+	if (p.fixed > 0 && d < calendar) || (p.fixed < 0 && d > calendar) {
+		return 0, fmt.Errorf("invalid duration %q: out of range", s)
+	}
+	return d, nil
+}
+
+// isoDuration is parsed ISO 8601 duration
+type isoDuration struct {
+	negative      bool
+	years, months int64
+	// fixed is the signed duration of weeks, days, hours, minutes and seconds
+	fixed time.Duration
+}
+
+// isoDateUnits and isoTimeUnits are the units of date and time parts in the required order
+const (
+	isoDateUnits = "YMWD"
+	isoTimeUnits = "HMS"
+)
+
+// parseISODuration parses ISO 8601 duration PnYnMnWnDTnHnMnS
+func parseISODuration(s string) (isoDuration, error) {
+	var p isoDuration
+	fail := func(format string, args ...interface{}) (isoDuration, error) {
+		return isoDuration{}, fmt.Errorf("invalid duration %q: %s", s, fmt.Sprintf(format, args...))
+	}
+	rest := s
+	if strings.HasPrefix(rest, "-") {
+		p.negative = true
+		rest = rest[1:]
+	}
+	if !strings.HasPrefix(rest, "P") {
+		return fail("must start with P")
+	}
+	rest = rest[1:]
+	if rest == "" {
+		return fail("no components")
+	}
+
+	units := isoDateUnits
+	last := -1
+	var fixed int64
+	for rest != "" {
+		if rest[0] == 'T' {
+			if units == isoTimeUnits {
+				return fail("repeated T")
+			}
+			units, last = isoTimeUnits, -1
+			rest = rest[1:]
+			if rest == "" {
+				return fail("no components after T")
+			}
+			continue
+		}
+
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return fail("expected number at %q", rest)
+		}
+		number, frac := rest[:i], ""
+		if i < len(rest) && (rest[i] == '.' || rest[i] == ',') {
+			j := i + 1
+			for j < len(rest) && rest[j] >= '0' && rest[j] <= '9' {
+				j++
+			}
+			if j == i+1 {
+				return fail("missing digits after %q", rest[:i+1])
+			}
+			frac, i = rest[i+1:j], j
+		}
+		if i == len(rest) {
+			return fail("missing unit after %q", rest)
+		}
+		unit := rest[i]
+		rest = rest[i+1:]
+
+		idx := strings.IndexByte(units, unit)
+		if idx < 0 {
+			if units == isoDateUnits && strings.IndexByte(isoTimeUnits, unit) >= 0 {
+				return fail("%c must follow T", unit)
+			}
+			return fail("unknown unit %q", unit)
+		}
+		if idx <= last {
+			return fail("%c is repeated or out of order", unit)
+		}
+		last = idx
+		if frac != "" && !(units == isoTimeUnits && unit == 'S') {
+			return fail("only seconds can be fractional")
+		}
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return fail("%s is out of range", number)
+		}
+
+		var scale int64
+		switch {
+		case units == isoDateUnits && unit == 'Y':
+			p.years = n
+			continue
+		case units == isoDateUnits && unit == 'M':
+			p.months = n
+			continue
+		case unit == 'W':
+			scale = int64(7 * 24 * time.Hour)
+		case unit == 'D':
+			scale = int64(24 * time.Hour)
+		case unit == 'H':
+			scale = int64(time.Hour)
+		case unit == 'M':
+			scale = int64(time.Minute)
+		case unit == 'S':
+			scale = int64(time.Second)
+		}
+		if n > (math.MaxInt64-fixed)/scale {
+			return fail("out of range")
+		}
+		fixed += n * scale
+		if frac != "" {
+			// nanoseconds, digits beyond nanosecond precision are truncated
+			frac = (frac + "000000000")[:9]
+			ns, _ := strconv.ParseInt(frac, 10, 64)
+			if ns > math.MaxInt64-fixed {
+				return fail("out of range")
+			}
+			fixed += ns
+		}
+	}
+	p.fixed = time.Duration(fixed)
+	if p.negative {
+		p.fixed = -p.fixed
+	}
+	return p, nil
+}
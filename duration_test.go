@@ -0,0 +1,105 @@
+package finding
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseEbayDuration(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"P2DT23H32M51S":   2*24*time.Hour + 23*time.Hour + 32*time.Minute + 51*time.Second,
+		"PT5M":            5 * time.Minute,
+		"P1W":             7 * 24 * time.Hour,
+		"P1W2D":           9 * 24 * time.Hour,
+		"PT1.5S":          1500 * time.Millisecond,
+		"PT0,000000001S":  time.Nanosecond,
+		"PT1.0000000019S": time.Second + time.Nanosecond,
+		"P0Y0M1D":         24 * time.Hour,
+		"-PT1H":           -time.Hour,
+		"P0D":             0,
+	} {
+		got, err := ParseEbayDuration(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, want, got, s)
+		}
+	}
+}
+
+func TestParseEbayDurationErrors(t *testing.T) {
+	for s, want := range map[string]string{
+		"":                       "must start with P",
+		"2DT1H":                  "must start with P",
+		"P":                      "no components",
+		"P1DT":                   "no components after T",
+		"PT1HT1M":                "repeated T",
+		"P1H":                    "H must follow T",
+		"PT1D":                   `unknown unit 'D'`,
+		"P1D1W":                  "W is repeated or out of order",
+		"PT1M1M":                 "M is repeated or out of order",
+		"P1.5D":                  "only seconds can be fractional",
+		"PT1.S":                  `missing digits after "1."`,
+		"PT15":                   `missing unit after "15"`,
+		"PTH":                    `expected number at "H"`,
+		"P1X":                    `unknown unit 'X'`,
+		"P1M":                    "years and months have no fixed length, use ParseEbayDurationAt",
+		"P2Y":                    "years and months have no fixed length, use ParseEbayDurationAt",
+		"PT9999999999999999999S": "9999999999999999999 is out of range",
+		"P99999999W":             "out of range",
+	} {
+		_, err := ParseEbayDuration(s)
+		assert.EqualError(t, err, fmt.Sprintf("invalid duration %q: %s", s, want), s)
+	}
+}
+
+func TestParseEbayDurationAt(t *testing.T) {
+	ref := time.Date(2021, 1, 31, 12, 0, 0, 0, time.UTC)
+	d, err := ParseEbayDurationAt("P1MT1H", ref)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 3, 3, 13, 0, 0, 0, time.UTC), ref.Add(d))
+
+	d, err = ParseEbayDurationAt("-P1Y", ref)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 31, 12, 0, 0, 0, time.UTC), ref.Add(d))
+
+	// month before T isn't minutes
+	d, err = ParseEbayDurationAt("P1M", ref)
+	assert.NoError(t, err)
+	assert.Equal(t, 31*24*time.Hour, d)
+}
+
+// formatTestDuration formats d in hours, minutes and seconds with nanoseconds
+func formatTestDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	return fmt.Sprintf("%sPT%dH%dM%d.%09dS", sign, d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second, d%time.Second)
+}
+
+func FuzzParseEbayDuration(f *testing.F) {
+	for _, s := range []string{"P2DT23H32M51S", "PT1.5S", "P1W", "P1Y2M3W4DT5H6M7.89S", "-PT1H", "P", "PT", "P1M", "PT1,5S"} {
+		f.Add(s)
+	}
+	ref := time.Date(2021, 11, 27, 0, 28, 30, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := ParseEbayDuration(s)
+		at, errAt := ParseEbayDurationAt(s, ref)
+		if err != nil {
+			return
+		}
+		// durations without years and months don't depend on the reference time
+		if errAt != nil || at != d {
+			t.Fatalf("ParseEbayDurationAt(%q) = %v, %v; ParseEbayDuration = %v", s, at, errAt, d)
+		}
+		if d == time.Duration(-1<<63) {
+			return
+		}
+		formatted := formatTestDuration(d)
+		back, err := ParseEbayDuration(formatted)
+		if err != nil || back != d {
+			t.Fatalf("%q parsed as %v, but its format %q parsed as %v, %v", s, d, formatted, back, err)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package finding
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError is an error or a warning reported by eBay in errorMessage of the response
+type APIError struct {
+	ErrorID     string
+	Domain      string
+	Subdomain   string
+	Severity    ErrorSeverity
+	Category    ErrorCategory
+	Message     string
+	ExceptionID string
+	Parameters  []Parameter
+}
+
+// newAPIError creates APIError from errorMessage>error entity of the response
+func newAPIError(e Error) *APIError {
+	return &APIError{
+		ErrorID:     e.ErrorID,
+		Domain:      e.Domain,
+		Subdomain:   e.Subdomain,
+		Severity:    ErrorSeverity(e.Severity),
+		Category:    ErrorCategory(e.Category),
+		Message:     e.Message,
+		ExceptionID: e.ExceptionID,
+		Parameters:  e.Parameters,
+	}
+}
+
+// Error implements error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ebay %s %s (domain %s, subdomain %s, category %s): %s",
+		strings.ToLower(string(e.Severity)), e.ErrorID, e.Domain, e.Subdomain, e.Category, e.Message)
+}
+
+// APIErrors is returned when eBay acknowledges the call with Failure or PartialFailure.
+// It contains all errors and warnings from errorMessage of the response.
+type APIErrors struct {
+	Ack    AckValue
+	Errors []*APIError
+}
+
+// Error implements error interface
+func (e *APIErrors) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("ebay ack %s", e.Ack)
+	}
+	msgs := make([]string, 0, len(e.Errors))
+	for _, apiErr := range e.Errors {
+		msgs = append(msgs, apiErr.Error())
+	}
+	return fmt.Sprintf("ebay ack %s: %s", e.Ack, strings.Join(msgs, "; "))
+}
+
+// As makes errors.As(err, **APIError) to find the first error reported by eBay
+func (e *APIErrors) As(target interface{}) bool {
+	t, ok := target.(**APIError)
+	if !ok || len(e.Errors) == 0 {
+		return false
+	}
+	*t = e.Errors[0]
+	for _, apiErr := range e.Errors {
+		if apiErr.Severity == ErrorSeverityError {
+			*t = apiErr
+			break
+		}
+	}
+	return true
+}
+
+// Has checks if eBay reported an error with given errorId
+func (e *APIErrors) Has(errorID string) bool {
+	return e.Find(errorID) != nil
+}
+
+// Find returns the error with given errorId or nil
+func (e *APIErrors) Find(errorID string) *APIError {
+	for _, apiErr := range e.Errors {
+		if apiErr.ErrorID == errorID {
+			return apiErr
+		}
+	}
+	return nil
+}
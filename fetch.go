@@ -0,0 +1,143 @@
+package finding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// fetchAll fetches the first page to learn the number of pages and then fetches the rest pages with workers.
+// Items are returned in page order.
+func fetchAll(ctx context.Context, workers int, fetch pageFetcher) ([]Item, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx = withValidationTime(ctx)
+	first, err := fetch(ctx, 1)
+	if err != nil {
+		return nil, fmt.Errorf("fetching page 1: %w", err)
+	}
+	pagination := first.pagination()
+	pages := pagination.TotalPages
+	if pages > MaxPages {
+		pages = MaxPages
+	}
+	if pages < 1 {
+		pages = 1
+	}
+	results := make([][]Item, pages+1)
+	results[1] = first.items()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pageCh := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		fetchErr error
+	)
+	for i := 0; i < workers && i < pages-1; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pageCh {
+				res, err := fetch(ctx, page)
+				if err != nil {
+					errOnce.Do(func() {
+						fetchErr = fmt.Errorf("fetching page %d: %w", page, err)
+						cancel()
+					})
+					continue
+				}
+				// every worker writes its own pages only
+				results[page] = res.items()
+			}
+		}()
+	}
+sendPages:
+	for page := 2; page <= pages; page++ {
+		select {
+		case pageCh <- page:
+		case <-ctx.Done():
+			break sendPages
+		}
+	}
+	close(pageCh)
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var items []Item
+	for _, pageItems := range results {
+		items = append(items, pageItems...)
+	}
+	if pagination.TotalPages > pages {
+		return items, &TruncatedError{
+			TotalEntries: pagination.TotalEntries,
+			TotalPages:   pagination.TotalPages,
+			Retrieved:    len(items),
+		}
+	}
+	return items, nil
+}
+
+/*
+==============================================================================
+*/
+
+// FetchAll fetches all pages of AdvancedRequest concurrently with given number of workers
+// and returns items in page order. The first page is fetched first to learn the number of pages.
+// It respects ctx cancellation and the rate limiter of the Service.
+// If the results are truncated by the Finding API ceiling, retrieved items are returned with *TruncatedError.
+// AdvancedRequest isn't modified by fetching pages, but it must not be changed until FetchAll returns.
+func (sr *AdvancedRequest) FetchAll(ctx context.Context, workers int) ([]Item, error) {
+	return fetchAll(ctx, workers, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// FetchAll fetches all pages of ByCategoryRequest concurrently (see AdvancedRequest.FetchAll)
+func (sr *ByCategoryRequest) FetchAll(ctx context.Context, workers int) ([]Item, error) {
+	return fetchAll(ctx, workers, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// FetchAll fetches all pages of ByKeywordsRequest concurrently (see AdvancedRequest.FetchAll)
+func (sr *ByKeywordsRequest) FetchAll(ctx context.Context, workers int) ([]Item, error) {
+	return fetchAll(ctx, workers, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// FetchAll fetches all pages of ByProductRequest concurrently (see AdvancedRequest.FetchAll)
+func (sr *ByProductRequest) FetchAll(ctx context.Context, workers int) ([]Item, error) {
+	return fetchAll(ctx, workers, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// FetchAll fetches all pages of InEbayStoresRequest concurrently (see AdvancedRequest.FetchAll)
+func (sr *InEbayStoresRequest) FetchAll(ctx context.Context, workers int) ([]Item, error) {
+	return fetchAll(ctx, workers, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// FetchAll fetches all pages of CompletedItemsRequest concurrently (see AdvancedRequest.FetchAll)
+func (sr *CompletedItemsRequest) FetchAll(ctx context.Context, workers int) ([]Item, error) {
+	return fetchAll(ctx, workers, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
@@ -0,0 +1,87 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAdvancedRequest_FetchAll(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 95, 10)
+	s := NewService("").WithEndpoint(ts.URL).WithPageLimit(10)
+	r := s.NewAdvancedRequest()
+	r.WithItemFilterSeller("seller")
+
+	items, err := r.FetchAll(context.Background(), 4)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, items, 95) {
+		for i, item := range items {
+			assert.Equal(t, fmt.Sprintf("%d-%d", i/10+1, i%10), item.ItemID)
+		}
+	}
+	assert.EqualValues(t, 10, calls)
+	assert.Zero(t, r.PaginationInput.PageNumber)
+}
+
+func TestFetchAll_AllRequestTypes(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 5, 2)
+	s := NewService("").WithEndpoint(ts.URL).WithPageLimit(2)
+	ctx := context.Background()
+
+	fetchers := map[string]func() ([]Item, error){
+		"ByCategory":     func() ([]Item, error) { return s.NewByCategoryRequest().FetchAll(ctx, 2) },
+		"ByKeywords":     func() ([]Item, error) { return s.NewByKeywordsRequest().FetchAll(ctx, 2) },
+		"ByProduct":      func() ([]Item, error) { return s.NewByProductRequest().FetchAll(ctx, 2) },
+		"InEbayStores":   func() ([]Item, error) { return s.NewInEbayStoresRequest().FetchAll(ctx, 2) },
+		"CompletedItems": func() ([]Item, error) { return s.NewCompletedItemsRequest().FetchAll(ctx, 2) },
+	}
+	for name, fetch := range fetchers {
+		t.Run(name, func(t *testing.T) {
+			items, err := fetch()
+			assert.NoError(t, err)
+			assert.Len(t, items, 5)
+		})
+	}
+}
+
+func TestByCategoryRequest_FetchAllTruncated(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 500, 1)
+	s := NewService("").WithEndpoint(ts.URL).WithPageLimit(1).WithRateLimit(10000, 10)
+
+	items, err := s.NewByCategoryRequest().FetchAll(context.Background(), 8)
+	var truncErr *TruncatedError
+	assert.True(t, errors.As(err, &truncErr))
+	assert.Len(t, items, MaxPages)
+	assert.EqualValues(t, MaxPages, calls)
+}
+
+func TestAdvancedRequest_FetchAllError(t *testing.T) {
+	var calls int32
+	ts := newSequenceServer(t, &calls,
+		[]int{http.StatusOK, http.StatusBadRequest},
+		[]string{pagingResponse("findItemsAdvanced", 1, 50, 5), "bad request"})
+	s := NewService("").WithEndpoint(ts.URL)
+
+	_, err := s.NewAdvancedRequest().FetchAll(context.Background(), 3)
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+}
+
+func TestAdvancedRequest_FetchAllCanceled(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 50, 5)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.NewAdvancedRequest().FetchAll(ctx, 3)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
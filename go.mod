@@ -1,8 +1,15 @@
 module github.com/hotafrika/ebay-finding-api
 
-go 1.16
+go 1.23
 
 require (
 	github.com/go-resty/resty/v2 v2.7.0
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.0.0-20211029224645-99673261e6eb // indirect
 )
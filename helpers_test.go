@@ -0,0 +1,171 @@
+package finding
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newServer starts server which responds calls with status and body returned by respond.
+// call is the 0-based number of the call and body is the request body.
+// Calls and new connections are counted into calls and conns unless they are nil.
+func newServer(tb testing.TB, calls *int32, conns *int64, respond func(call int, r *http.Request, body []byte) (int, string)) *httptest.Server {
+	tb.Helper()
+	var n int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&n, 1)) - 1
+		if calls != nil {
+			atomic.AddInt32(calls, 1)
+		}
+		body, _ := io.ReadAll(r.Body)
+		status, resp := respond(call, r, body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(resp))
+	}))
+	if conns != nil {
+		ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt64(conns, 1)
+			}
+		}
+	}
+	ts.Start()
+	tb.Cleanup(ts.Close)
+	return ts
+}
+
+// newTestServer starts server which responds every call with given status and body
+func newTestServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	return newServer(t, nil, nil, func(int, *http.Request, []byte) (int, string) {
+		return status, body
+	})
+}
+
+// newSequenceServer starts server which responds calls with given statuses and bodies in order.
+// The last response is repeated.
+func newSequenceServer(t *testing.T, calls *int32, statuses []int, bodies []string) *httptest.Server {
+	t.Helper()
+	return newServer(t, calls, nil, func(call int, _ *http.Request, _ []byte) (int, string) {
+		i := min(call, len(statuses)-1)
+		return statuses[i], bodies[i]
+	})
+}
+
+// newCountingServer starts server which counts new connections
+func newCountingServer(tb testing.TB, conns *int64) *httptest.Server {
+	tb.Helper()
+	return newServer(tb, nil, conns, func(int, *http.Request, []byte) (int, string) {
+		return http.StatusOK, testAdvancedResponse
+	})
+}
+
+// newPagingServer starts server which responds find* calls with perPage items per page.
+// Item IDs are "<page>-<index>". totalEntries is reported as is, even if it exceeds the Finding API ceiling.
+func newPagingServer(t *testing.T, calls *int32, totalEntries, perPage int) *httptest.Server {
+	t.Helper()
+	return newServer(t, calls, nil, func(_ int, _ *http.Request, body []byte) (int, string) {
+		var req struct {
+			XMLName         xml.Name
+			PaginationInput ServicePaginationInput `xml:"paginationInput"`
+		}
+		if err := xml.Unmarshal(body, &req); err != nil {
+			return http.StatusBadRequest, ""
+		}
+		page := req.PaginationInput.PageNumber
+		return http.StatusOK, pagingResponse(strings.TrimSuffix(req.XMLName.Local, "Request"), page, totalEntries, perPage)
+	})
+}
+
+// pagingResponse creates response of operation for page #
+func pagingResponse(operation string, page, totalEntries, perPage int) string {
+	totalPages := (totalEntries + perPage - 1) / perPage
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf(`<%sResponse xmlns="http://www.ebay.com/marketplace/search/v1/services"><ack>Success</ack><searchResult>`, operation))
+	for i := 0; i < perPage && (page-1)*perPage+i < totalEntries; i++ {
+		sb.WriteString(fmt.Sprintf("<item><itemId>%d-%d</itemId></item>", page, i))
+	}
+	sb.WriteString(fmt.Sprintf(`</searchResult><paginationOutput><pageNumber>%d</pageNumber><entriesPerPage>%d</entriesPerPage>`+
+		`<totalPages>%d</totalPages><totalEntries>%d</totalEntries></paginationOutput></%sResponse>`,
+		page, perPage, totalPages, totalEntries, operation))
+	return sb.String()
+}
+
+// crawlItem is an item of the search served by newCrawlServer
+type crawlItem struct {
+	id        string
+	category  string
+	price     float64
+	startTime time.Time
+}
+
+// newCrawlServer starts server which searches items by MinPrice, MaxPrice, StartTimeFrom, StartTimeTo and categoryId.
+// Category histogram lists categories of found items.
+func newCrawlServer(t *testing.T, calls *int32, items []crawlItem) *httptest.Server {
+	t.Helper()
+	return newServer(t, calls, nil, func(_ int, _ *http.Request, body []byte) (int, string) {
+		var req struct {
+			XMLName         xml.Name
+			CategoryID      []string               `xml:"categoryId"`
+			ItemFilter      []ServiceItemFilter    `xml:"itemFilter"`
+			PaginationInput ServicePaginationInput `xml:"paginationInput"`
+		}
+		if err := xml.Unmarshal(body, &req); err != nil {
+			return http.StatusBadRequest, ""
+		}
+		minPrice, maxPrice := 0.0, math.Inf(1)
+		startFrom, startTo := time.Time{}, time.Unix(1<<40, 0)
+		for _, f := range req.ItemFilter {
+			switch ItemFilterParameter(f.Name) {
+			case ItemFilterMinPrice:
+				minPrice, _ = strconv.ParseFloat(f.Value[0], 64)
+			case ItemFilterMaxPrice:
+				maxPrice, _ = strconv.ParseFloat(f.Value[0], 64)
+			case ItemFilterStartTimeFrom:
+				startFrom, _ = FromEbayDateTime(f.Value[0])
+			case ItemFilterStartTimeTo:
+				startTo, _ = FromEbayDateTime(f.Value[0])
+			}
+		}
+		var found []crawlItem
+		counts := make(map[string]int)
+		for _, item := range items {
+			if item.price < minPrice || item.price > maxPrice ||
+				item.startTime.Before(startFrom) || item.startTime.After(startTo) {
+				continue
+			}
+			if len(req.CategoryID) > 0 && !strings.HasPrefix(item.category, req.CategoryID[0]) {
+				continue
+			}
+			found = append(found, item)
+			counts[item.category]++
+		}
+
+		operation := strings.TrimSuffix(req.XMLName.Local, "Request")
+		perPage := req.PaginationInput.EntriesPerPage
+		page := req.PaginationInput.PageNumber
+		sb := strings.Builder{}
+		sb.WriteString(fmt.Sprintf(`<%sResponse xmlns="http://www.ebay.com/marketplace/search/v1/services"><ack>Success</ack>`, operation))
+		sb.WriteString("<categoryHistogramContainer>")
+		for category, count := range counts {
+			sb.WriteString(fmt.Sprintf("<categoryHistogram><categoryId>%s</categoryId><count>%d</count></categoryHistogram>", category, count))
+		}
+		sb.WriteString("</categoryHistogramContainer><searchResult>")
+		for i := (page - 1) * perPage; i < page*perPage && i < len(found); i++ {
+			sb.WriteString(fmt.Sprintf("<item><itemId>%s</itemId></item>", found[i].id))
+		}
+		sb.WriteString(fmt.Sprintf(`</searchResult><paginationOutput><pageNumber>%d</pageNumber><entriesPerPage>%d</entriesPerPage>`+
+			`<totalPages>%d</totalPages><totalEntries>%d</totalEntries></paginationOutput></%sResponse>`,
+			page, perPage, (len(found)+perPage-1)/perPage, len(found), operation))
+		return http.StatusOK, sb.String()
+	})
+}
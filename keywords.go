@@ -0,0 +1,250 @@
+package finding
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of keywords in characters
+const (
+	KeywordsMaxLength     = 350
+	KeywordsMinLength     = 2
+	KeywordsMaxWordLength = 98
+)
+
+// keywordsSpecialChars have special meaning in keywords and are quoted in terms
+const keywordsSpecialChars = `(),*@`
+
+// KeywordExpr is a part of keywords built with Term, Phrase, Wildcard, AnyOf and Exclude
+type KeywordExpr interface {
+	// render returns eBay syntax of the expression
+	render() (string, error)
+	// words returns words of the expression counted against KeywordsMaxWordLength
+	words() []string
+}
+
+// KeywordsError is returned by Keywords for the part of keywords breaking the syntax or the limits
+type KeywordsError struct {
+	// Part is the index of the expression passed to Keywords
+	Part int
+	Msg  string
+}
+
+// Error implements error interface
+func (e *KeywordsError) Error() string {
+	return fmt.Sprintf("keywords part %d: %s", e.Part, e.Msg)
+}
+
+// keywordTerm is a single word
+type keywordTerm string
+
+// Term matches the word. Words with special characters ( ) , * @ or starting with - are quoted.
+// The word can't contain whitespace or ".
+func Term(word string) KeywordExpr {
+	return keywordTerm(word)
+}
+
+func (t keywordTerm) render() (string, error) {
+	word := string(t)
+	if err := checkKeywordText(word); err != nil {
+		return "", err
+	}
+	if strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("term %q contains whitespace, use Phrase", word)
+	}
+	if strings.ContainsAny(word, keywordsSpecialChars) || strings.HasPrefix(word, "-") {
+		return `"` + word + `"`, nil
+	}
+	return word, nil
+}
+
+func (t keywordTerm) words() []string {
+	return []string{string(t)}
+}
+
+// keywordPhrase is an exact phrase
+type keywordPhrase string
+
+// Phrase matches the words in the exact order. Whitespace between the words is collapsed.
+// The phrase can't contain ".
+func Phrase(text string) KeywordExpr {
+	return keywordPhrase(text)
+}
+
+func (p keywordPhrase) render() (string, error) {
+	if err := checkKeywordText(string(p)); err != nil {
+		return "", err
+	}
+	return `"` + strings.Join(p.words(), " ") + `"`, nil
+}
+
+func (p keywordPhrase) words() []string {
+	return strings.Fields(string(p))
+}
+
+// keywordWildcard is a prefix of words
+type keywordWildcard string
+
+// Wildcard matches words starting with the prefix (prefix*).
+// The prefix can't contain whitespace, " or special characters ( ) , * @.
+func Wildcard(prefix string) KeywordExpr {
+	return keywordWildcard(prefix)
+}
+
+func (w keywordWildcard) render() (string, error) {
+	prefix := string(w)
+	if err := checkKeywordText(prefix); err != nil {
+		return "", err
+	}
+	if strings.IndexFunc(prefix, unicode.IsSpace) >= 0 || strings.ContainsAny(prefix, keywordsSpecialChars) ||
+		strings.HasPrefix(prefix, "-") {
+		return "", fmt.Errorf("wildcard prefix %q contains special characters", prefix)
+	}
+	return prefix + "*", nil
+}
+
+func (w keywordWildcard) words() []string {
+	return []string{string(w) + "*"}
+}
+
+// keywordAnyOf is a group of alternatives
+type keywordAnyOf []KeywordExpr
+
+// AnyOf matches any of the terms, phrases or wildcards ((a,b)). Groups can't be nested.
+func AnyOf(exprs ...KeywordExpr) KeywordExpr {
+	return keywordAnyOf(exprs)
+}
+
+func (g keywordAnyOf) render() (string, error) {
+	if len(g) == 0 {
+		return "", errors.New("AnyOf is empty")
+	}
+	parts := make([]string, len(g))
+	for i, expr := range g {
+		switch expr.(type) {
+		case keywordTerm, keywordPhrase, keywordWildcard:
+		default:
+			return "", errors.New("AnyOf accepts only terms, phrases and wildcards")
+		}
+		part, err := expr.render()
+		if err != nil {
+			return "", err
+		}
+		parts[i] = part
+	}
+	return "(" + strings.Join(parts, ",") + ")", nil
+}
+
+func (g keywordAnyOf) words() []string {
+	var words []string
+	for _, expr := range g {
+		words = append(words, expr.words()...)
+	}
+	return words
+}
+
+// keywordExclude excludes an expression
+type keywordExclude struct {
+	expr KeywordExpr
+}
+
+// Exclude removes items matching the term, phrase, wildcard or any of AnyOf group (-a, -(a,b)).
+func Exclude(expr KeywordExpr) KeywordExpr {
+	return keywordExclude{expr: expr}
+}
+
+func (e keywordExclude) render() (string, error) {
+	switch e.expr.(type) {
+	case keywordTerm, keywordPhrase, keywordWildcard, keywordAnyOf:
+	default:
+		return "", errors.New("Exclude accepts only terms, phrases, wildcards and AnyOf")
+	}
+	s, err := e.expr.render()
+	if err != nil {
+		return "", err
+	}
+	return "-" + s, nil
+}
+
+func (e keywordExclude) words() []string {
+	if e.expr == nil {
+		return nil
+	}
+	return e.expr.words()
+}
+
+// checkKeywordText checks text of terms, phrases and wildcards
+func checkKeywordText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("empty text")
+	}
+	if strings.Contains(text, `"`) {
+		return fmt.Errorf("%q can't contain \" character", text)
+	}
+	return nil
+}
+
+// Keywords renders the expressions in eBay keywords syntax, separated by spaces:
+//
+//	Keywords(Phrase("harry potter"), AnyOf(Term("dvd"), Term("blu-ray")), Exclude(Term("poster")))
+//
+// returns `"harry potter" (dvd,blu-ray) -poster`.
+// Lengths are counted in characters (runes): keywords must be from 2 to 350 characters and every word up to 98.
+// The first expression breaking the syntax or the limits is reported as *KeywordsError.
+func Keywords(exprs ...KeywordExpr) (string, error) {
+	var b strings.Builder
+	for i, expr := range exprs {
+		if expr == nil {
+			return "", &KeywordsError{Part: i, Msg: "nil expression"}
+		}
+		s, err := expr.render()
+		if err != nil {
+			return "", &KeywordsError{Part: i, Msg: err.Error()}
+		}
+		for _, word := range expr.words() {
+			if utf8.RuneCountInString(word) > KeywordsMaxWordLength {
+				return "", &KeywordsError{Part: i, Msg: fmt.Sprintf("word %q is longer than %d characters", word, KeywordsMaxWordLength)}
+			}
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s)
+		if n := utf8.RuneCountInString(b.String()); n > KeywordsMaxLength {
+			return "", &KeywordsError{Part: i, Msg: fmt.Sprintf("keywords are %d characters long, longer than %d", n, KeywordsMaxLength)}
+		}
+	}
+	if n := utf8.RuneCountInString(b.String()); n < KeywordsMinLength {
+		return "", &KeywordsError{Part: max(len(exprs)-1, 0), Msg: fmt.Sprintf("keywords are shorter than %d characters", KeywordsMinLength)}
+	}
+	return b.String(), nil
+}
+
+// truncateRunes returns s cut to n runes
+func truncateRunes(s string, n int) string {
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos]
+		}
+		i++
+	}
+	return s
+}
+
+// violations checks length of keywords words
+func (sr *RequestKeywords) violations() []Violation {
+	var vs []Violation
+	for _, word := range strings.Fields(sr.Keywords) {
+		if utf8.RuneCountInString(word) > KeywordsMaxWordLength {
+			vs = append(vs, Violation{
+				Fields:  []string{"keywords"},
+				Message: fmt.Sprintf("word %q is longer than %d characters", word, KeywordsMaxWordLength),
+			})
+		}
+	}
+	return vs
+}
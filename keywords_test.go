@@ -0,0 +1,57 @@
+package finding
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestKeywords(t *testing.T) {
+	kw, err := Keywords(Phrase("harry  potter"), AnyOf(Term("dvd"), Term("blu-ray"), Wildcard("book")),
+		Exclude(Term("poster")), Exclude(AnyOf(Phrase("box set"), Term("(used)"))), Term("-10%"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"harry potter" (dvd,blu-ray,book*) -poster -("box set","(used)") "-10%"`, kw)
+}
+
+func TestKeywordsErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		exprs []KeywordExpr
+		want  *KeywordsError
+	}{
+		"quote": {[]KeywordExpr{Term("a"), Phrase(`say "hi"`)},
+			&KeywordsError{Part: 1, Msg: `"say \"hi\"" can't contain " character`}},
+		"space in term": {[]KeywordExpr{Term("a b")},
+			&KeywordsError{Part: 0, Msg: `term "a b" contains whitespace, use Phrase`}},
+		"nested": {[]KeywordExpr{AnyOf(Term("a"), AnyOf(Term("b")))},
+			&KeywordsError{Part: 0, Msg: "AnyOf accepts only terms, phrases and wildcards"}},
+		"empty": {[]KeywordExpr{Term("ab"), AnyOf()},
+			&KeywordsError{Part: 1, Msg: "AnyOf is empty"}},
+		"wildcard": {[]KeywordExpr{Wildcard("a,b")},
+			&KeywordsError{Part: 0, Msg: `wildcard prefix "a,b" contains special characters`}},
+		"word": {[]KeywordExpr{Term("ab"), Exclude(Term(strings.Repeat("я", 99)))},
+			&KeywordsError{Part: 1, Msg: `word "` + strings.Repeat("я", 99) + `" is longer than 98 characters`}},
+		"total": {[]KeywordExpr{Phrase(strings.Repeat("ab ", 100)), Phrase(strings.Repeat("яя ", 20))},
+			&KeywordsError{Part: 1, Msg: "keywords are 363 characters long, longer than 350"}},
+		"short": {[]KeywordExpr{Term("a")},
+			&KeywordsError{Part: 0, Msg: "keywords are shorter than 2 characters"}},
+	} {
+		_, err := Keywords(tt.exprs...)
+		var kwErr *KeywordsError
+		if assert.True(t, errors.As(err, &kwErr), name) {
+			assert.Equal(t, tt.want, kwErr, name)
+		}
+	}
+}
+
+func TestRequestKeywords_WithKeywordsRunes(t *testing.T) {
+	r := NewService("").NewByKeywordsRequest()
+	r.WithKeywords(strings.Repeat("ё ", 200))
+	assert.Equal(t, strings.Repeat("ё ", 175), r.Keywords)
+
+	r.WithKeywords("ё")
+	assert.Equal(t, strings.Repeat("ё ", 175), r.Keywords)
+
+	r.WithKeywords("harry " + strings.Repeat("ё", 99))
+	assert.EqualError(t, r.Validate(), `invalid request: keywords: word "`+strings.Repeat("ё", 99)+`" is longer than 98 characters`)
+}
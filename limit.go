@@ -0,0 +1,390 @@
+package finding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimiter limits the rate of calls to the Finding API
+type RateLimiter interface {
+	// Wait blocks until the call is allowed or ctx is done
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a RateLimiter which allows calls at rate per second with bursts of up to burst calls
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates new TokenBucket which is full initially.
+// Rate is a number of calls per second. Burst below 1 is changed to 1.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until the token is available or ctx is done
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	tb.mu.Lock()
+	now := time.Now()
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.last = now
+	tb.tokens--
+	var wait time.Duration
+	if tb.tokens < 0 {
+		if tb.rate <= 0 {
+			tb.tokens++
+			tb.mu.Unlock()
+			return errors.New("rate limit doesn't allow any calls")
+		}
+		wait = time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+	}
+	tb.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		// give the token back
+		tb.mu.Lock()
+		tb.tokens++
+		tb.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+/*
+================================================================
+*/
+
+// ErrQuotaExhausted is returned before sending the call if the daily call quota is used
+var ErrQuotaExhausted = errors.New("daily call quota exhausted")
+
+// QuotaStore keeps the number of calls made per day.
+// Days are keys in format 2006-01-02 (UTC).
+type QuotaStore interface {
+	// Reserve increments the number of calls made in the day if it is below limit and returns the new number.
+	// It returns ErrQuotaExhausted if limit is reached.
+	Reserve(day string, limit int) (int, error)
+	// Used returns the number of calls made in the day
+	Used(day string) (int, error)
+}
+
+// ContextQuotaStore is QuotaStore which stops waiting (e.g. for a lock) when ctx is done.
+// Quota uses ReserveContext of such stores for calls with context.
+type ContextQuotaStore interface {
+	QuotaStore
+	// ReserveContext is Reserve which returns ctx.Err() if ctx is done before the call is reserved
+	ReserveContext(ctx context.Context, day string, limit int) (int, error)
+}
+
+// Quota tracks calls made per UTC day against the daily limit
+type Quota struct {
+	limit int
+	store QuotaStore
+	now   func() time.Time
+}
+
+// NewQuota creates new Quota with limit of calls per UTC day. Calls are counted in store.
+// If store is nil, MemoryQuotaStore is used.
+func NewQuota(limit int, store QuotaStore) *Quota {
+	if store == nil {
+		store = NewMemoryQuotaStore()
+	}
+	return &Quota{
+		limit: limit,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// day returns current UTC day key
+func (q *Quota) day() string {
+	return q.now().UTC().Format("2006-01-02")
+}
+
+// Reserve counts one call. It returns ErrQuotaExhausted if the daily limit is reached.
+func (q *Quota) Reserve() error {
+	return q.ReserveContext(context.Background())
+}
+
+// ReserveContext counts one call like Reserve. Waiting of ContextQuotaStore is stopped when ctx is done.
+func (q *Quota) ReserveContext(ctx context.Context) error {
+	if store, ok := q.store.(ContextQuotaStore); ok {
+		_, err := store.ReserveContext(ctx, q.day(), q.limit)
+		return err
+	}
+	_, err := q.store.Reserve(q.day(), q.limit)
+	return err
+}
+
+// Used returns the number of calls made today (UTC)
+func (q *Quota) Used() (int, error) {
+	return q.store.Used(q.day())
+}
+
+// Remaining returns the number of calls which can be made today (UTC)
+func (q *Quota) Remaining() (int, error) {
+	used, err := q.Used()
+	if err != nil {
+		return 0, err
+	}
+	if used >= q.limit {
+		return 0, nil
+	}
+	return q.limit - used, nil
+}
+
+/*
+================================================================
+*/
+
+// MemoryQuotaStore is QuotaStore which keeps counters in memory
+type MemoryQuotaStore struct {
+	mu   sync.Mutex
+	days map[string]int
+}
+
+// NewMemoryQuotaStore creates new MemoryQuotaStore
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{days: make(map[string]int)}
+}
+
+// Reserve increments the number of calls made in the day if it is below limit
+func (ms *MemoryQuotaStore) Reserve(day string, limit int) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	used := ms.days[day]
+	if used >= limit {
+		return used, ErrQuotaExhausted
+	}
+	used++
+	ms.days[day] = used
+	return used, nil
+}
+
+// Used returns the number of calls made in the day
+func (ms *MemoryQuotaStore) Used(day string) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.days[day], nil
+}
+
+/*
+================================================================
+*/
+
+// fileQuotaStoreDays is the number of last days kept by FileQuotaStore
+const fileQuotaStoreDays = 7
+
+// FileQuotaStore is QuotaStore which keeps counters in JSON file,
+// so separate processes using the same file share the daily quota.
+// The file is locked with a sibling ".lock" file while it is updated.
+type FileQuotaStore struct {
+	path string
+	// LockTimeout is maximum time of waiting for the lock. Default: 10 seconds.
+	LockTimeout time.Duration
+	// StaleLockAge is the age after which the lock is considered abandoned and removed. Default: 30 seconds.
+	StaleLockAge time.Duration
+}
+
+// NewFileQuotaStore creates new FileQuotaStore which keeps counters in file by path
+func NewFileQuotaStore(path string) *FileQuotaStore {
+	return &FileQuotaStore{
+		path:         path,
+		LockTimeout:  10 * time.Second,
+		StaleLockAge: 30 * time.Second,
+	}
+}
+
+// Reserve increments the number of calls made in the day if it is below limit
+func (fs *FileQuotaStore) Reserve(day string, limit int) (int, error) {
+	return fs.ReserveContext(context.Background(), day, limit)
+}
+
+// ReserveContext increments the number of calls made in the day if it is below limit.
+// Waiting for the lock is stopped when ctx is done.
+func (fs *FileQuotaStore) ReserveContext(ctx context.Context, day string, limit int) (int, error) {
+	unlock, err := fs.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	days, err := fs.read()
+	if err != nil {
+		return 0, err
+	}
+	used := days[day]
+	if used >= limit {
+		return used, ErrQuotaExhausted
+	}
+	used++
+	days[day] = used
+	return used, fs.write(days)
+}
+
+// Used returns the number of calls made in the day
+func (fs *FileQuotaStore) Used(day string) (int, error) {
+	unlock, err := fs.lock(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	days, err := fs.read()
+	if err != nil {
+		return 0, err
+	}
+	return days[day], nil
+}
+
+// lockTokens makes lock tokens unique within the process
+var lockTokens uint64
+
+// lock creates lock file with unique token and returns function removing it.
+// It waits until the lock is released, LockTimeout passes or ctx is done.
+func (fs *FileQuotaStore) lock(ctx context.Context) (func(), error) {
+	lockPath := fs.path + ".lock"
+	token := fmt.Sprintf("%d-%d-%d", os.Getpid(), time.Now().UnixNano(), atomic.AddUint64(&lockTokens, 1))
+	timeout := time.NewTimer(fs.LockTimeout)
+	defer timeout.Stop()
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, err = f.WriteString(token)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(lockPath)
+				return nil, fmt.Errorf("locking quota file: %w", err)
+			}
+			return func() { fs.unlock(lockPath, token) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("locking quota file: %w", err)
+		}
+		if fs.breakStaleLock(lockPath, token) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("locking quota file: %w", ctx.Err())
+		case <-timeout.C:
+			return nil, fmt.Errorf("locking quota file: timeout after %s", fs.LockTimeout)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// unlock removes the lock if it is still held with token
+func (fs *FileQuotaStore) unlock(lockPath, token string) {
+	if data, err := os.ReadFile(lockPath); err == nil && string(data) == token {
+		_ = os.Remove(lockPath)
+	}
+}
+
+// breakStaleLock removes the lock older than StaleLockAge and reports whether it was removed.
+// The lock is renamed to a name unique to the caller first and its age is checked again on the renamed file,
+// so the decision is made on the file the caller has taken: a stale lock is removed, a fresh one (a live lock
+// created after the first check) is put back.
+// A window remains: if another process creates the lock while the live one is renamed away, the live lock
+// can't be put back and both processes hold the lock until they release it.
+func (fs *FileQuotaStore) breakStaleLock(lockPath, token string) bool {
+	if info, err := os.Stat(lockPath); err != nil || time.Since(info.ModTime()) <= fs.StaleLockAge {
+		return false
+	}
+	stalePath := lockPath + ".stale." + token
+	if err := os.Rename(lockPath, stalePath); err != nil {
+		// another process has broken the lock
+		return false
+	}
+	if info, err := os.Stat(stalePath); err == nil && time.Since(info.ModTime()) <= fs.StaleLockAge {
+		// the lock was replaced by a live one: put it back unless the lock is taken again
+		if err := os.Link(stalePath, lockPath); err != nil && !os.IsExist(err) {
+			_ = os.Rename(stalePath, lockPath)
+		}
+		_ = os.Remove(stalePath)
+		return false
+	}
+	_ = os.Remove(stalePath)
+	return true
+}
+
+// read reads counters from the file. Missing file means no calls.
+func (fs *FileQuotaStore) read() (map[string]int, error) {
+	days := make(map[string]int)
+	data, err := os.ReadFile(fs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return days, nil
+		}
+		return nil, fmt.Errorf("reading quota file: %w", err)
+	}
+	if len(data) == 0 {
+		return days, nil
+	}
+	if err := json.Unmarshal(data, &days); err != nil {
+		return nil, fmt.Errorf("parsing quota file: %w", err)
+	}
+	return days, nil
+}
+
+// write atomically replaces the file with counters of the last fileQuotaStoreDays days
+func (fs *FileQuotaStore) write(days map[string]int) error {
+	keys := make([]string, 0, len(days))
+	for day := range days {
+		keys = append(keys, day)
+	}
+	sort.Strings(keys)
+	for len(keys) > fileQuotaStoreDays {
+		delete(days, keys[0])
+		keys = keys[1:]
+	}
+	data, err := json.Marshal(days)
+	if err != nil {
+		return fmt.Errorf("serializing quota file: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(fs.path), filepath.Base(fs.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("writing quota file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing quota file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing quota file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fs.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing quota file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	tb := NewTokenBucket(100, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, tb.Wait(context.Background()))
+	}
+	// 2 calls are allowed at once, next 2 calls wait 10ms each
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(15*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.True(t, errors.Is(tb.Wait(ctx), context.Canceled))
+}
+
+func TestService_WithDailyQuota(t *testing.T) {
+	var calls int32
+	ts := newSequenceServer(t, &calls, []int{http.StatusOK}, []string{testAdvancedResponse})
+	s := NewService("").WithEndpoint(ts.URL).WithRateLimit(1000, 1).WithDailyQuota(2, nil)
+
+	r := s.NewAdvancedRequest()
+	for i := 0; i < 2; i++ {
+		_, err := r.Execute()
+		assert.NoError(t, err)
+	}
+	_, err := s.NewGetVersionRequest().Execute()
+	assert.True(t, errors.Is(err, ErrQuotaExhausted), "got %v", err)
+	assert.EqualValues(t, 2, calls)
+
+	remaining, err := s.Quota().Remaining()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, remaining)
+}
+
+func TestQuota_NextDay(t *testing.T) {
+	q := NewQuota(1, nil)
+	now := time.Date(2021, 11, 27, 23, 59, 0, 0, time.UTC)
+	q.now = func() time.Time { return now }
+
+	assert.NoError(t, q.Reserve())
+	assert.True(t, errors.Is(q.Reserve(), ErrQuotaExhausted))
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, q.Reserve())
+}
+
+func TestFileQuotaStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	store1 := NewFileQuotaStore(path)
+	store2 := NewFileQuotaStore(path)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(store QuotaStore) {
+			defer wg.Done()
+			_, _ = store.Reserve("2021-11-27", 15)
+		}([]QuotaStore{store1, store2}[i%2])
+	}
+	wg.Wait()
+
+	used, err := store2.Used("2021-11-27")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, used)
+
+	for i := 0; i < 5; i++ {
+		_, err = store1.Reserve("2021-11-27", 15)
+		assert.NoError(t, err)
+	}
+	_, err = store2.Reserve("2021-11-27", 15)
+	assert.True(t, errors.Is(err, ErrQuotaExhausted))
+
+	used, err = store1.Used("2021-11-28")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, used)
+}
+
+func TestFileQuotaStore_StaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	lockPath := path + ".lock"
+	assert.NoError(t, os.WriteFile(lockPath, []byte("dead"), 0o600))
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(lockPath, old, old))
+
+	// processes seeing the same stale lock get the lock one by one
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := NewFileQuotaStore(path).Reserve("2021-11-27", 100)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	used, err := NewFileQuotaStore(path).Used("2021-11-27")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, used)
+	_, err = os.Stat(lockPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileQuotaStore_BreakStaleLock(t *testing.T) {
+	fs := NewFileQuotaStore(filepath.Join(t.TempDir(), "quota.json"))
+	lockPath := fs.path + ".lock"
+	assert.NoError(t, os.WriteFile(lockPath, []byte("live"), 0o600))
+	assert.False(t, fs.breakStaleLock(lockPath, "a"))
+	data, err := os.ReadFile(lockPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "live", string(data))
+
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(lockPath, old, old))
+	assert.True(t, fs.breakStaleLock(lockPath, "a"))
+	assert.False(t, fs.breakStaleLock(lockPath, "b"))
+	matches, err := filepath.Glob(lockPath + "*")
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestFileQuotaStore_LockContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	assert.NoError(t, os.WriteFile(path+".lock", []byte("live"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := NewQuota(10, NewFileQuotaStore(path)).ReserveContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// the lock of another process isn't removed
+	data, err := os.ReadFile(path + ".lock")
+	assert.NoError(t, err)
+	assert.Equal(t, "live", string(data))
+}
@@ -0,0 +1,214 @@
+package finding
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// moneyScale is the number of minor units in a major unit. All currencies of the Finding API have 2 decimal places.
+const moneyScale = 100
+
+// Money is an amount of money in minor units (e.g. cents) of the currency.
+// Unlike float64 prices, sums and comparisons of Money are exact.
+type Money struct {
+	// Minor is the amount in minor units: 1234 is 12.34
+	Minor int64
+	// Currency is ISO 4217 currency code (e.g. USD)
+	Currency string
+}
+
+// ParseMoney parses decimal amount (e.g. 12.34) of the currency.
+// Amounts with more than 2 decimal places are rounded half away from zero.
+func ParseMoney(amount, currency string) (Money, error) {
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	var units int64
+	if whole != "" {
+		var err error
+		if units, err = strconv.ParseInt(whole, 10, 64); err != nil || units > math.MaxInt64/moneyScale-1 {
+			return Money{}, fmt.Errorf("amount %q is out of range", amount)
+		}
+	}
+	frac += "000"
+	cents, _ := strconv.ParseInt(frac[:2], 10, 64)
+	minor := units*moneyScale + cents
+	if frac[2] >= '5' {
+		minor++
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// isDigits checks that s has only ASCII digits
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MoneyFromFloat converts float amount of the currency to Money rounding it to minor units
+func MoneyFromFloat(amount float64, currency string) Money {
+	return Money{Minor: int64(math.Round(amount * moneyScale)), Currency: currency}
+}
+
+// Float64 returns the amount in major units. Use it for display only: float64 sums aren't exact.
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / moneyScale
+}
+
+// Decimal formats the amount with 2 decimal places (e.g. 12.34)
+func (m Money) Decimal() string {
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/moneyScale, minor%moneyScale)
+}
+
+// String implements fmt.Stringer interface (e.g. 12.34 USD)
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.Currency
+}
+
+// IsZero checks if the amount is zero
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+// Add returns the sum of the amounts. Amounts must have the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	sum := m.Minor + o.Minor
+	if (sum > m.Minor) != (o.Minor > 0) {
+		return Money{}, errors.New("money overflow")
+	}
+	return Money{Minor: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of the amounts. Amounts must have the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(Money{Minor: -o.Minor, Currency: o.Currency})
+}
+
+// Cmp compares the amounts: -1 if m < o, 0 if m == o, +1 if m > o. Amounts must have the same currency.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Minor < o.Minor:
+		return -1, nil
+	case m.Minor > o.Minor:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// sameCurrency checks that the amounts can be added or compared
+func (m Money) sameCurrency(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("currency mismatch: %s and %s", m.Currency, o.Currency)
+	}
+	return nil
+}
+
+// SumMoney returns the sum of the amounts of the same currency. The sum of no amounts is zero without currency.
+func SumMoney(amounts ...Money) (Money, error) {
+	if len(amounts) == 0 {
+		return Money{}, nil
+	}
+	sum := amounts[0]
+	for _, m := range amounts[1:] {
+		var err error
+		if sum, err = sum.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
+
+// xmlAmount is eBay amount element: <currentPrice currencyId="USD">12.34</currentPrice>
+type xmlAmount struct {
+	Value      string `xml:",chardata"`
+	CurrencyID string `xml:"currencyId,attr"`
+}
+
+// money parses the amount. Empty amount is zero.
+func (a xmlAmount) money() (Money, error) {
+	if strings.TrimSpace(a.Value) == "" {
+		return Money{Currency: a.CurrencyID}, nil
+	}
+	return ParseMoney(a.Value, a.CurrencyID)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface for eBay amount elements
+func (m *Money) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var a xmlAmount
+	if err := d.DecodeElement(&a, &start); err != nil {
+		return err
+	}
+	money, err := a.money()
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", start.Name.Local, err)
+	}
+	*m = money
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface. Amount is set together with Value and CurrencyID.
+func (p *Price) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var a xmlAmount
+	if err := d.DecodeElement(&a, &start); err != nil {
+		return err
+	}
+	money, err := a.money()
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", start.Name.Local, err)
+	}
+	var value float64
+	if s := strings.TrimSpace(a.Value); s != "" {
+		if value, err = strconv.ParseFloat(s, 64); err != nil {
+			return fmt.Errorf("parsing %s: %w", start.Name.Local, err)
+		}
+	}
+	*p = Price{Value: value, CurrencyID: a.CurrencyID, Amount: money}
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+// OriginalRetailPriceAmount is set together with OriginalRetailPrice.
+func (dpi *DiscountPriceInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type discountPriceInfo DiscountPriceInfo
+	var v struct {
+		discountPriceInfo
+		OriginalRetailPrice Price `xml:"originalRetailPrice"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*dpi = DiscountPriceInfo(v.discountPriceInfo)
+	dpi.OriginalRetailPrice = v.OriginalRetailPrice.Value
+	dpi.OriginalRetailPriceAmount = v.OriginalRetailPrice.Amount
+	return nil
+}
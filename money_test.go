@@ -0,0 +1,92 @@
+package finding
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	for amount, want := range map[string]int64{
+		"12.34":  1234,
+		"12":     1200,
+		"0.1":    10,
+		"-3.5":   -350,
+		"0.995":  100,
+		"19.994": 1999,
+		".5":     50,
+	} {
+		m, err := ParseMoney(amount, "USD")
+		if assert.NoError(t, err, amount) {
+			assert.Equal(t, Money{Minor: want, Currency: "USD"}, m, amount)
+		}
+	}
+	for _, amount := range []string{"", ".", "1,5", "abc", "1e3", "99999999999999999999"} {
+		_, err := ParseMoney(amount, "USD")
+		assert.Error(t, err, amount)
+	}
+}
+
+func TestMoney_Operations(t *testing.T) {
+	// 0.1 added 1000 times is exactly 100.00
+	sum := Money{Currency: "USD"}
+	for i := 0; i < 1000; i++ {
+		var err error
+		sum, err = sum.Add(Money{Minor: 10, Currency: "USD"})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "100.00 USD", sum.String())
+
+	diff, err := Money{Minor: 5, Currency: "EUR"}.Sub(Money{Minor: 1205, Currency: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, "-12.00", diff.Decimal())
+
+	cmp, err := Money{Minor: 5, Currency: "EUR"}.Cmp(Money{Minor: 6, Currency: "EUR"})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	_, err = Money{Minor: 5, Currency: "EUR"}.Add(Money{Minor: 5, Currency: "USD"})
+	assert.EqualError(t, err, "currency mismatch: EUR and USD")
+	_, err = SumMoney(Money{Minor: 5, Currency: "EUR"}, Money{Minor: 5, Currency: "USD"})
+	assert.Error(t, err)
+
+	assert.Equal(t, Money{Minor: 1999, Currency: "GBP"}, MoneyFromFloat(19.99, "GBP"))
+}
+
+func TestPrice_UnmarshalXML(t *testing.T) {
+	var item Item
+	err := xml.Unmarshal([]byte(`<item>
+		<sellingStatus><currentPrice currencyId="USD">19.99</currentPrice></sellingStatus>
+		<shippingInfo><shippingServiceCost currencyId="USD">0.0</shippingServiceCost></shippingInfo>
+		<discountPriceInfo><originalRetailPrice currencyId="USD">29.95</originalRetailPrice></discountPriceInfo>
+	</item>`), &item)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, Price{Value: 19.99, CurrencyID: "USD", Amount: Money{Minor: 1999, Currency: "USD"}},
+		item.SellingStatus.CurrentPrice)
+	assert.Equal(t, Money{Currency: "USD"}, item.ShippingInfo.ShippingServiceCost.Amount)
+	assert.Equal(t, 29.95, item.DiscountPriceInfo.OriginalRetailPrice)
+	assert.Equal(t, Money{Minor: 2995, Currency: "USD"}, item.DiscountPriceInfo.OriginalRetailPriceAmount)
+
+	err = xml.Unmarshal([]byte(`<item><sellingStatus><currentPrice currencyId="USD">x</currentPrice></sellingStatus></item>`), &item)
+	assert.Error(t, err)
+}
+
+func TestRequestItemFilter_PriceMoney(t *testing.T) {
+	r := NewService("").WithStrictMode(true).NewAdvancedRequest()
+	r.WithItemFilterMinPriceMoney(Money{Minor: 1050, Currency: "EUR"})
+	r.WithItemFilterMaxPriceMoney(Money{Minor: 2000})
+	assert.Equal(t, []ServiceItemFilter{
+		{Name: "MaxPrice", Value: []string{"20.00"}},
+		{Name: "MinPrice", Value: []string{"10.50"}, ParamName: "Currency", ParamValue: "EUR"},
+	}, r.ItemFilters())
+	assert.NoError(t, r.Err())
+
+	r.WithItemFilterMaxPriceMoney(Money{Minor: 2000, Currency: "JPY"})
+	assert.EqualError(t, r.Err(), `altered input: WithItemFilterMaxPriceMoney: currency "JPY" isn't supported`)
+
+	r.WithItemFilterMaxPriceWithCurrency(20, "JPY")
+	assert.EqualError(t, r.Validate(), `invalid request: MaxPrice: currency "JPY" isn't supported; `+
+		"MinPrice, MaxPrice: must have the same currency")
+}
@@ -0,0 +1,240 @@
+package finding
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// pagedResponse is a response of find* requests
+type pagedResponse interface {
+	items() []Item
+	pagination() PaginationOutput
+}
+
+// items returns found items
+func (rsr ResponseSearchResult) items() []Item {
+	return rsr.SearchResult.Items
+}
+
+// pagination returns pagination of the result set
+func (rpo ResponsePaginationOutput) pagination() PaginationOutput {
+	return rpo.PaginationOutput
+}
+
+// pageFetcher fetches page # of the search
+type pageFetcher func(ctx context.Context, page int) (pagedResponse, error)
+
+// TruncatedError is reported by Pager and item iterators after the last retrieved item
+// when the search matches more items than the Finding API can return (MaxPages pages or MaxEntries items).
+type TruncatedError struct {
+	// TotalEntries is the total number of items matching the search
+	TotalEntries int
+	// TotalPages is the total number of pages matching the search
+	TotalPages int
+	// Retrieved is the number of items retrieved
+	Retrieved int
+}
+
+// Error implements error interface
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("results truncated by the Finding API: retrieved %d of %d items (%d pages)",
+		e.Retrieved, e.TotalEntries, e.TotalPages)
+}
+
+// Pager retrieves items of the search page by page. Pages are fetched lazily.
+// Pager stops at the last page or at the Finding API ceiling (MaxPages pages, MaxEntries items).
+// Pager isn't safe for concurrent use.
+//
+//	p := r.Pager(ctx)
+//	for p.Next() {
+//		item := p.Item()
+//	}
+//	var truncErr *finding.TruncatedError
+//	if err := p.Err(); err != nil && !errors.As(err, &truncErr) {
+//		return err
+//	}
+type Pager struct {
+	ctx        context.Context
+	fetch      pageFetcher
+	items      []Item
+	item       Item
+	page       int
+	pagination PaginationOutput
+	retrieved  int
+	err        error
+	done       bool
+}
+
+// newPager creates new Pager
+func newPager(ctx context.Context, fetch pageFetcher) *Pager {
+	return &Pager{
+		ctx:   withValidationTime(ctx),
+		fetch: fetch,
+	}
+}
+
+// Next advances Pager to the next item fetching the next page if needed.
+// It returns false when there are no more items or an error occurred.
+func (p *Pager) Next() bool {
+	for len(p.items) == 0 {
+		if p.done {
+			return false
+		}
+		p.fetchNext()
+	}
+	p.item = p.items[0]
+	p.items = p.items[1:]
+	p.retrieved++
+	return true
+}
+
+// fetchNext fetches the next page or marks Pager as done
+func (p *Pager) fetchNext() {
+	if p.page > 0 {
+		if p.page >= p.pagination.TotalPages {
+			p.done = true
+			return
+		}
+		if p.page >= MaxPages || p.retrieved >= MaxEntries {
+			p.done = true
+			p.err = &TruncatedError{
+				TotalEntries: p.pagination.TotalEntries,
+				TotalPages:   p.pagination.TotalPages,
+				Retrieved:    p.retrieved,
+			}
+			return
+		}
+	}
+	res, err := p.fetch(p.ctx, p.page+1)
+	if err != nil {
+		p.done = true
+		p.err = fmt.Errorf("fetching page %d: %w", p.page+1, err)
+		return
+	}
+	p.page++
+	p.pagination = res.pagination()
+	p.items = res.items()
+	if len(p.items) == 0 {
+		p.done = true
+	}
+}
+
+// Item returns the current item
+func (p *Pager) Item() Item {
+	return p.item
+}
+
+// Page returns the number of the last fetched page
+func (p *Pager) Page() int {
+	return p.page
+}
+
+// PaginationOutput returns pagination of the last fetched page
+func (p *Pager) PaginationOutput() PaginationOutput {
+	return p.pagination
+}
+
+// Err returns the error which stopped Pager.
+// If the results are truncated by the Finding API ceiling, Err returns *TruncatedError.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// itemsSeq creates iterator over items of new Pager.
+// The last element has an error if Pager is stopped by error (including *TruncatedError).
+func itemsSeq(newPager func() *Pager) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		p := newPager()
+		for p.Next() {
+			if !yield(p.Item(), nil) {
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			yield(Item{}, err)
+		}
+	}
+}
+
+/*
+==============================================================================
+*/
+
+// Pager creates Pager over items of AdvancedRequest
+func (sr *AdvancedRequest) Pager(ctx context.Context) *Pager {
+	return newPager(ctx, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// Items iterates over items of all pages of AdvancedRequest (see Pager)
+func (sr *AdvancedRequest) Items(ctx context.Context) iter.Seq2[Item, error] {
+	return itemsSeq(func() *Pager { return sr.Pager(ctx) })
+}
+
+// Pager creates Pager over items of ByCategoryRequest
+func (sr *ByCategoryRequest) Pager(ctx context.Context) *Pager {
+	return newPager(ctx, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// Items iterates over items of all pages of ByCategoryRequest (see Pager)
+func (sr *ByCategoryRequest) Items(ctx context.Context) iter.Seq2[Item, error] {
+	return itemsSeq(func() *Pager { return sr.Pager(ctx) })
+}
+
+// Pager creates Pager over items of ByKeywordsRequest
+func (sr *ByKeywordsRequest) Pager(ctx context.Context) *Pager {
+	return newPager(ctx, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// Items iterates over items of all pages of ByKeywordsRequest (see Pager)
+func (sr *ByKeywordsRequest) Items(ctx context.Context) iter.Seq2[Item, error] {
+	return itemsSeq(func() *Pager { return sr.Pager(ctx) })
+}
+
+// Pager creates Pager over items of ByProductRequest
+func (sr *ByProductRequest) Pager(ctx context.Context) *Pager {
+	return newPager(ctx, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// Items iterates over items of all pages of ByProductRequest (see Pager)
+func (sr *ByProductRequest) Items(ctx context.Context) iter.Seq2[Item, error] {
+	return itemsSeq(func() *Pager { return sr.Pager(ctx) })
+}
+
+// Pager creates Pager over items of InEbayStoresRequest
+func (sr *InEbayStoresRequest) Pager(ctx context.Context) *Pager {
+	return newPager(ctx, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// Items iterates over items of all pages of InEbayStoresRequest (see Pager)
+func (sr *InEbayStoresRequest) Items(ctx context.Context) iter.Seq2[Item, error] {
+	return itemsSeq(func() *Pager { return sr.Pager(ctx) })
+}
+
+// Pager creates Pager over items of CompletedItemsRequest
+func (sr *CompletedItemsRequest) Pager(ctx context.Context) *Pager {
+	return newPager(ctx, func(ctx context.Context, page int) (pagedResponse, error) {
+		res, err := sr.GetPageContext(ctx, page)
+		return res, err
+	})
+}
+
+// Items iterates over items of all pages of CompletedItemsRequest (see Pager)
+func (sr *CompletedItemsRequest) Items(ctx context.Context) iter.Seq2[Item, error] {
+	return itemsSeq(func() *Pager { return sr.Pager(ctx) })
+}
@@ -0,0 +1,73 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAdvancedRequest_Items(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 7, 3)
+	s := NewService("").WithEndpoint(ts.URL).WithPageLimit(3)
+
+	var ids []string
+	for item, err := range s.NewAdvancedRequest().Items(context.Background()) {
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids = append(ids, item.ItemID)
+	}
+	assert.Equal(t, []string{"1-0", "1-1", "1-2", "2-0", "2-1", "2-2", "3-0"}, ids)
+	assert.EqualValues(t, 3, calls)
+}
+
+func TestByKeywordsRequest_ItemsBreak(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 100, 10)
+	s := NewService("").WithEndpoint(ts.URL).WithPageLimit(10)
+
+	n := 0
+	for _, err := range s.NewByKeywordsRequest().Items(context.Background()) {
+		assert.NoError(t, err)
+		n++
+		if n == 15 {
+			break
+		}
+	}
+	assert.EqualValues(t, 2, calls)
+}
+
+func TestByCategoryRequest_PagerTruncated(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 250, 2)
+	s := NewService("").WithEndpoint(ts.URL).WithPageLimit(2)
+
+	p := s.NewByCategoryRequest().Pager(context.Background())
+	n := 0
+	for p.Next() {
+		n++
+	}
+	assert.Equal(t, 200, n)
+	assert.Equal(t, MaxPages, p.Page())
+	assert.EqualValues(t, MaxPages, calls)
+
+	var truncErr *TruncatedError
+	if assert.True(t, errors.As(p.Err(), &truncErr)) {
+		assert.Equal(t, 250, truncErr.TotalEntries)
+		assert.Equal(t, 125, truncErr.TotalPages)
+		assert.Equal(t, 200, truncErr.Retrieved)
+	}
+}
+
+func TestAdvancedRequest_PagerError(t *testing.T) {
+	ts := newTestServer(t, http.StatusOK, testFailureResponse)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	p := s.NewAdvancedRequest().Pager(context.Background())
+	assert.False(t, p.Next())
+	var apiErrs *APIErrors
+	assert.True(t, errors.As(p.Err(), &apiErrs))
+}
@@ -0,0 +1,135 @@
+package finding
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Request is any request of the Finding API
+type Request interface {
+	Validate() error
+	Err() error
+	GetBody() ([]byte, error)
+}
+
+// ParseRequest parses Finding API request body (e.g. captured GetBody output) into request of the service.
+// The request type is detected by the root element: findItemsAdvancedRequest is parsed into *AdvancedRequest,
+// findItemsByCategoryRequest into *ByCategoryRequest and so on. The request is ready to execute and
+// its builders can be used as if the request was built with them.
+// GetBody of the parsed request returns the same bytes as GetBody of the original request.
+func (s *Service) ParseRequest(body []byte) (Request, error) {
+	root, err := rootElement(body)
+	if err != nil {
+		return nil, err
+	}
+	var req Request
+	switch root {
+	case "findItemsAdvancedRequest":
+		r := s.NewAdvancedRequest()
+		basic := r.RequestBasic
+		*r = AdvancedRequest{}
+		err = xml.Unmarshal(body, r)
+		r.RequestBasic = basic
+		r.bindInputs(basic.inputs)
+		r.RequestItemFilter.load()
+		req = r
+	case "findItemsByCategoryRequest":
+		r := s.NewByCategoryRequest()
+		basic := r.RequestBasic
+		*r = ByCategoryRequest{}
+		err = xml.Unmarshal(body, r)
+		r.RequestBasic = basic
+		r.bindInputs(basic.inputs)
+		r.RequestItemFilter.load()
+		req = r
+	case "findItemsByKeywordsRequest":
+		r := s.NewByKeywordsRequest()
+		basic := r.RequestBasic
+		*r = ByKeywordsRequest{}
+		err = xml.Unmarshal(body, r)
+		r.RequestBasic = basic
+		r.bindInputs(basic.inputs)
+		r.RequestItemFilter.load()
+		req = r
+	case "findItemsByProductRequest":
+		r := s.NewByProductRequest()
+		basic := r.RequestBasic
+		*r = ByProductRequest{}
+		err = xml.Unmarshal(body, r)
+		r.RequestBasic = basic
+		r.bindInputs(basic.inputs)
+		r.RequestItemFilter.load()
+		req = r
+	case "findItemsIneBayStoresRequest":
+		r := s.NewInEbayStoresRequest()
+		basic := r.RequestBasic
+		*r = InEbayStoresRequest{}
+		err = xml.Unmarshal(body, r)
+		r.RequestBasic = basic
+		r.bindInputs(basic.inputs)
+		r.RequestItemFilter.load()
+		req = r
+	case "findCompletedItemsRequest":
+		r := s.NewCompletedItemsRequest()
+		basic := r.RequestBasic
+		*r = CompletedItemsRequest{}
+		err = xml.Unmarshal(body, r)
+		r.RequestBasic = basic
+		r.bindInputs(basic.inputs)
+		r.RequestItemFilter.load()
+		req = r
+	case "getHistogramsRequest":
+		r := s.NewGetHistogramsRequest()
+		err = xml.Unmarshal(body, r)
+		req = r
+	case "getSearchKeywordsRecommendationRequest":
+		r := s.NewGetKeywordsRecommendationRequest()
+		err = xml.Unmarshal(body, r)
+		req = r
+	case "getVersionRequest":
+		r := s.NewGetVersionRequest()
+		err = xml.Unmarshal(body, r)
+		req = r
+	default:
+		return nil, fmt.Errorf("unsupported request %q", root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", root, err)
+	}
+	return req, nil
+}
+
+// rootElement returns local name of the root element of XML body
+func rootElement(body []byte) (string, error) {
+	d := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return "", errors.New("unable to parse request: no root element")
+		}
+		if err != nil {
+			return "", fmt.Errorf("unable to parse request: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// load fills ItemFilterMap from parsed ItemFilter. Values of repeated item filters are merged.
+func (sr *RequestItemFilter) load() {
+	sr.Initialize()
+	for _, f := range sr.ItemFilter {
+		ifp := ItemFilterParameter(f.Name)
+		if existing, ok := sr.ItemFilterMap[ifp]; ok {
+			existing.Value = append(existing.Value, f.Value...)
+			sr.ItemFilterMap[ifp] = existing
+			continue
+		}
+		sr.ItemFilterMap[ifp] = f.clone()
+	}
+	sr.ItemFilter = nil
+}
@@ -0,0 +1,90 @@
+package finding
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestService_ParseRequest(t *testing.T) {
+	s := NewService("")
+	files, err := filepath.Glob(filepath.Join("testdata", "request", "xml", "*", "*.xml"))
+	if !assert.NoError(t, err) || !assert.NotEmpty(t, files) {
+		return
+	}
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if !assert.NoError(t, err) {
+				return
+			}
+			req, err := s.ParseRequest(data)
+			if !assert.NoError(t, err) {
+				return
+			}
+			body, err := req.GetBody()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// the same request as in the file
+			want := reflect.New(reflect.TypeOf(req).Elem()).Interface()
+			got := reflect.New(reflect.TypeOf(req).Elem()).Interface()
+			assert.NoError(t, xml.Unmarshal(data, want))
+			assert.NoError(t, xml.Unmarshal(body, got))
+			if f, ok := want.(interface{ ItemFilters() []ServiceItemFilter }); ok {
+				assert.ElementsMatch(t, f.ItemFilters(), got.(interface{ ItemFilters() []ServiceItemFilter }).ItemFilters())
+				for _, v := range []interface{}{want, got} {
+					field := reflect.ValueOf(v).Elem().FieldByName("ItemFilter")
+					field.Set(reflect.Zero(field.Type()))
+				}
+			}
+			assert.Equal(t, want, got)
+
+			// byte-identical roundtrip
+			req2, err := s.ParseRequest(body)
+			if !assert.NoError(t, err) {
+				return
+			}
+			body2, err := req2.GetBody()
+			assert.NoError(t, err)
+			assert.Equal(t, string(body), string(body2))
+		})
+	}
+}
+
+func TestService_ParseRequestBuilders(t *testing.T) {
+	s := NewService("")
+	r := s.NewAdvancedRequest()
+	r.WithKeywords("harry potter")
+	r.WithItemFilterCondition(ConditionNew)
+	r.WithItemFilterMaxPrice(10)
+	body, err := r.GetBody()
+	assert.NoError(t, err)
+
+	req, err := s.ParseRequest(body)
+	if !assert.NoError(t, err) {
+		return
+	}
+	parsed := req.(*AdvancedRequest)
+	assert.Equal(t, r.ItemFilterMap, parsed.ItemFilterMap)
+
+	parsed.WithItemFilterCondition(ConditionUsed)
+	parsed.RemoveItemFilter(ItemFilterMaxPrice)
+	assert.Equal(t, []ServiceItemFilter{
+		{Name: string(ItemFilterCondition), Value: []string{string(ConditionNew), string(ConditionUsed)}},
+	}, parsed.ItemFilters())
+}
+
+func TestService_ParseRequestErrors(t *testing.T) {
+	s := NewService("")
+	_, err := s.ParseRequest([]byte(`<findItemsUnknownRequest/>`))
+	assert.EqualError(t, err, `unsupported request "findItemsUnknownRequest"`)
+	_, err = s.ParseRequest(nil)
+	assert.Error(t, err)
+	_, err = s.ParseRequest([]byte(`<findItemsAdvancedRequest><keywords>a</findItemsAdvancedRequest>`))
+	assert.Error(t, err)
+}
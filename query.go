@@ -0,0 +1,533 @@
+package finding
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryError is a syntax error of a search query
+type QueryError struct {
+	// Offset is byte offset of the error in the query
+	Offset int
+	Msg    string
+}
+
+// Error implements error interface
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query: %s at offset %d", e.Msg, e.Offset)
+}
+
+// queryConditions are names of conditions in queries
+var queryConditions = []struct {
+	name      string
+	condition ItemFilterConditionOption
+}{
+	{"new", ConditionNew},
+	{"newother", ConditionNewOther},
+	{"newwithdefects", ConditionNewWithDefects},
+	{"certifiedrefurbished", ConditionCertifiedRefurbished},
+	{"excellentrefurbished", ConditionExcellentRefurbished},
+	{"verygoodrefurbished", ConditionVeryGoodRefurbished},
+	{"goodrefurbished", ConditionGoodRefurbished},
+	{"sellerrefurbished", ConditionSellerRefurbished},
+	{"likenew", ConditionLikeNew},
+	{"used", ConditionUsed},
+	{"verygood", ConditionVeryGood},
+	{"good", ConditionGood},
+	{"acceptable", ConditionAcceptable},
+	{"parts", ConditionForPartsOrNotWorking},
+}
+
+// querySortOrders are names of sort orders in queries. + means ascending, - means descending.
+var querySortOrders = []struct {
+	name  string
+	order SortOrderParameter
+}{
+	{"best", SortOrderBestMatch},
+	{"price+", SortOrderPricePlusShippingLowest},
+	{"price-", SortOrderPricePlusShippingHighest},
+	{"currentprice-", SortOrderCurrentPriceHighest},
+	{"ending", SortOrderEndTimeSoonest},
+	{"newest", SortOrderStartTimeNewest},
+	{"distance", SortOrderDistanceNearest},
+	{"bids+", SortOrderBidCountFewest},
+	{"bids-", SortOrderBidCountMost},
+	{"country+", SortOrderCountryAscending},
+	{"country-", SortOrderCountryDescending},
+	{"watchers-", SortOrderWatchCountDecreaseSort},
+}
+
+// queryListingTypes are names of listing types in queries
+var queryListingTypes = []struct {
+	name        string
+	listingType ItemFilterListingTypeOption
+}{
+	{"auction", ListingTypeAuction},
+	{"auctionbin", ListingTypeAuctionWithBIN},
+	{"bin", ListingTypeFixedPrice},
+	{"classified", ListingTypeClassified},
+	{"store", ListingTypeStoreInventory},
+	{"all", ListingTypeAll},
+}
+
+// queryQualifierOrder is the order of qualifiers in formatted queries
+var queryQualifierOrder = []string{"cat", "price", "cond", "type", "seller", "loc", "ship", "sort", "site"}
+
+// queryToken is a keyword or a qualifier of a query
+type queryToken struct {
+	offset int
+	text   string
+	// key and value of key:value qualifier
+	key, value string
+}
+
+// scanQuery splits query into tokens.
+// Tokens are separated by whitespace. "quoted phrases" may contain whitespace and may be excluded with -.
+// Unquoted tokens starting with letters followed by : are qualifiers.
+func scanQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+	i := 0
+	for i < len(query) {
+		if isQuerySpace(query[i]) {
+			i++
+			continue
+		}
+		start := i
+		if query[i] == '-' && i+1 < len(query) && query[i+1] == '"' {
+			i++
+		}
+		if query[i] == '"' {
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return nil, &QueryError{Offset: i, Msg: "unterminated quote"}
+			}
+			if end == 0 {
+				return nil, &QueryError{Offset: i, Msg: "empty phrase"}
+			}
+			i += end + 2
+			if i < len(query) && !isQuerySpace(query[i]) {
+				return nil, &QueryError{Offset: i, Msg: "missing space after phrase"}
+			}
+			tokens = append(tokens, queryToken{offset: start, text: query[start:i]})
+			continue
+		}
+		for i < len(query) && !isQuerySpace(query[i]) {
+			if query[i] == '"' {
+				return nil, &QueryError{Offset: i, Msg: "unexpected quote"}
+			}
+			i++
+		}
+		token := queryToken{offset: start, text: query[start:i]}
+		if key, value, ok := queryQualifier(token.text); ok {
+			token.key, token.value = key, value
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// queryQualifier splits key:value qualifier
+func queryQualifier(text string) (key, value string, ok bool) {
+	colon := strings.IndexByte(text, ':')
+	if colon < 1 {
+		return "", "", false
+	}
+	for _, r := range text[:colon] {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return "", "", false
+		}
+	}
+	return strings.ToLower(text[:colon]), text[colon+1:], true
+}
+
+// isQuerySpace reports whether c separates query tokens
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// ParseQuery compiles search query into AdvancedRequest of the service.
+//
+// Words, "quoted phrases" and -excluded words or phrases become keywords.
+// Qualifiers set the other fields:
+//
+//	price:10..100   MinPrice and MaxPrice, either bound may be omitted (price:10.., price:..100)
+//	cond:new,used   Condition: new, newother, newwithdefects, certifiedrefurbished, excellentrefurbished,
+//	                verygoodrefurbished, goodrefurbished, sellerrefurbished, likenew, used, verygood, good,
+//	                acceptable, parts or condition ID
+//	type:auction    ListingType: auction, auctionbin, bin, classified, store, all
+//	seller:a,-b     Seller, - excludes the seller (ExcludeSeller)
+//	loc:US,CA       LocatedIn
+//	cat:267,1       categoryId
+//	ship:free       FreeShippingOnly
+//	sort:price+     sortOrder: best, price+, price-, currentprice-, ending, newest, distance,
+//	                bids+, bids-, country+, country-, watchers-
+//	site:EBAY-DE    GlobalID of the request
+//
+// Syntax errors are returned as *QueryError.
+func (s *Service) ParseQuery(query string) (*AdvancedRequest, error) {
+	tokens, err := scanQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	req := s.NewAdvancedRequest()
+	var keywords []string
+	for _, token := range tokens {
+		if token.key == "" {
+			keywords = append(keywords, token.text)
+			continue
+		}
+		if token.value == "" {
+			return nil, &QueryError{Offset: token.offset, Msg: fmt.Sprintf("empty %s: value", token.key)}
+		}
+		if err = req.applyQualifier(token.key, token.value); err != nil {
+			return nil, &QueryError{Offset: token.offset + len(token.key) + 1, Msg: err.Error()}
+		}
+	}
+	if len(keywords) > 0 {
+		req.WithKeywords(strings.Join(keywords, " "))
+	}
+	return req, nil
+}
+
+// applyQualifier sets key:value qualifier of a query to the request
+func (sr *AdvancedRequest) applyQualifier(key, value string) error {
+	switch key {
+	case "price":
+		lo, hi, ok := strings.Cut(value, "..")
+		if !ok {
+			return fmt.Errorf("price %q isn't a range", value)
+		}
+		var min, max float64
+		var err error
+		if lo != "" {
+			if min, err = parseQueryPrice(lo); err != nil {
+				return err
+			}
+		}
+		if hi != "" {
+			if max, err = parseQueryPrice(hi); err != nil {
+				return err
+			}
+		}
+		if lo != "" && hi != "" && min > max {
+			return fmt.Errorf("price %q has minimum above maximum", value)
+		}
+		if lo != "" {
+			sr.WithItemFilterMinPrice(min)
+		}
+		if hi != "" {
+			sr.WithItemFilterMaxPrice(max)
+		}
+	case "cond":
+		names, err := queryList(value)
+		if err != nil {
+			return err
+		}
+		var conditions []ItemFilterConditionOption
+		for _, name := range names {
+			condition, ok := queryCondition(name)
+			if !ok {
+				return fmt.Errorf("unknown condition %q", name)
+			}
+			conditions = append(conditions, condition)
+		}
+		sr.WithItemFilterCondition(conditions...)
+	case "type":
+		names, err := queryList(value)
+		if err != nil {
+			return err
+		}
+		var listingTypes []ItemFilterListingTypeOption
+		for _, name := range names {
+			listingType, ok := queryListingType(name)
+			if !ok {
+				return fmt.Errorf("unknown listing type %q", name)
+			}
+			listingTypes = append(listingTypes, listingType)
+		}
+		sr.WithItemFilterListingType(listingTypes...)
+	case "seller":
+		sellers, err := queryList(value)
+		if err != nil {
+			return err
+		}
+		for _, seller := range sellers {
+			name := strings.TrimPrefix(seller, "-")
+			if name == "" {
+				return errors.New("empty seller")
+			}
+			if name != seller {
+				sr.WithItemFilterExcludeSeller(name)
+			} else {
+				sr.WithItemFilterSeller(name)
+			}
+		}
+	case "loc":
+		codes, err := queryList(strings.ToUpper(value))
+		if err != nil {
+			return err
+		}
+		sr.WithItemFilterLocatedIn(codes...)
+	case "cat":
+		categories, err := queryList(value)
+		if err != nil {
+			return err
+		}
+		sr.WithCategoriesID(categories...)
+	case "ship":
+		if value != "free" {
+			return fmt.Errorf("unknown shipping %q", value)
+		}
+		sr.WithItemFilterFreeShippingOnly(true)
+	case "sort":
+		order, ok := querySortOrder(value)
+		if !ok {
+			return fmt.Errorf("unknown sort order %q", value)
+		}
+		sr.WithSortOrder(order)
+	case "site":
+		globalID := GlobalID(strings.ToUpper(value))
+		if _, ok := siteCountries[globalID]; !ok {
+			return fmt.Errorf("unknown site %q", value)
+		}
+		sr.WithGlobalID(globalID)
+	default:
+		return fmt.Errorf("unknown qualifier %q", key)
+	}
+	return nil
+}
+
+// queryList splits comma-separated list of qualifier values
+func queryList(value string) ([]string, error) {
+	values := strings.Split(value, ",")
+	for _, v := range values {
+		if v == "" {
+			return nil, fmt.Errorf("empty value in list %q", value)
+		}
+	}
+	return values, nil
+}
+
+// parseQueryPrice parses price bound of a query
+func parseQueryPrice(s string) (float64, error) {
+	price, err := strconv.ParseFloat(s, 64)
+	if err != nil || price < 0 {
+		return 0, fmt.Errorf("invalid price %q", s)
+	}
+	return price, nil
+}
+
+// queryCondition returns condition by its name or ID
+func queryCondition(name string) (ItemFilterConditionOption, bool) {
+	name = strings.ToLower(name)
+	for _, c := range queryConditions {
+		if c.name == name {
+			return c.condition, true
+		}
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return ItemFilterConditionOption(name), true
+	}
+	return "", false
+}
+
+// queryListingType returns listing type by its name
+func queryListingType(name string) (ItemFilterListingTypeOption, bool) {
+	name = strings.ToLower(name)
+	for _, lt := range queryListingTypes {
+		if lt.name == name {
+			return lt.listingType, true
+		}
+	}
+	return "", false
+}
+
+// querySortOrder returns sort order by its name
+func querySortOrder(name string) (SortOrderParameter, bool) {
+	name = strings.ToLower(name)
+	for _, so := range querySortOrders {
+		if so.name == name {
+			return so.order, true
+		}
+	}
+	return "", false
+}
+
+/*
+==============================================================================
+*/
+
+// Query formats AdvancedRequest in the query language of Service.ParseQuery.
+// Pagination isn't part of the query. Fields and item filters the language can't express
+// (e.g. aspectFilter, MinPrice with currency) are reported by the error.
+func (sr *AdvancedRequest) Query() (string, error) {
+	var parts, unsupported []string
+	qualifiers := map[string]string{}
+
+	if sr.Keywords != "" {
+		tokens, err := scanQuery(sr.Keywords)
+		if err != nil {
+			return "", fmt.Errorf("keywords %q can't be expressed in query: %w", sr.Keywords, err)
+		}
+		for _, token := range tokens {
+			if token.key != "" {
+				return "", fmt.Errorf("keyword %q can't be expressed in query", token.text)
+			}
+			parts = append(parts, token.text)
+		}
+	}
+	if len(sr.CategoryID) > 0 {
+		qualifiers["cat"] = strings.Join(sr.CategoryID, ",")
+	}
+
+	var sellers []string
+	var price [2]string
+	for _, f := range sr.ItemFilters() {
+		if len(f.Value) == 0 {
+			continue
+		}
+		if f.ParamName != "" {
+			unsupported = append(unsupported, f.Name)
+			continue
+		}
+		switch ItemFilterParameter(f.Name) {
+		case ItemFilterMinPrice:
+			price[0] = queryPrice(f.Value[0])
+		case ItemFilterMaxPrice:
+			price[1] = queryPrice(f.Value[0])
+		case ItemFilterCondition:
+			names, ok := queryConditionNames(f.Value)
+			if !ok {
+				unsupported = append(unsupported, f.Name)
+				continue
+			}
+			qualifiers["cond"] = strings.Join(names, ",")
+		case ItemFilterListingType:
+			names, ok := queryListingTypeNames(f.Value)
+			if !ok {
+				unsupported = append(unsupported, f.Name)
+				continue
+			}
+			qualifiers["type"] = strings.Join(names, ",")
+		case ItemFilterSeller:
+			sellers = append(sellers, f.Value...)
+		case ItemFilterExcludeSeller:
+			for _, seller := range f.Value {
+				sellers = append(sellers, "-"+seller)
+			}
+		case ItemFilterLocatedIn:
+			qualifiers["loc"] = strings.Join(f.Value, ",")
+		case ItemFilterFreeShippingOnly:
+			if f.Value[0] == "true" {
+				qualifiers["ship"] = "free"
+			}
+		default:
+			unsupported = append(unsupported, f.Name)
+		}
+	}
+	if price[0] != "" || price[1] != "" {
+		qualifiers["price"] = price[0] + ".." + price[1]
+	}
+	if len(sellers) > 0 {
+		qualifiers["seller"] = strings.Join(sellers, ",")
+	}
+
+	if sr.SortOrder != "" {
+		name, ok := querySortOrderName(SortOrderParameter(sr.SortOrder))
+		if ok {
+			qualifiers["sort"] = name
+		} else {
+			unsupported = append(unsupported, "sortOrder")
+		}
+	}
+	if sr.site != "" {
+		qualifiers["site"] = string(sr.site)
+	}
+
+	for _, key := range queryQualifierOrder {
+		if value, ok := qualifiers[key]; ok {
+			parts = append(parts, key+":"+value)
+		}
+	}
+
+	if sr.DescriptionSearch {
+		unsupported = append(unsupported, "descriptionSearch")
+	}
+	if len(sr.AspectFilter) > 0 {
+		unsupported = append(unsupported, "aspectFilter")
+	}
+	if len(sr.OutputSelector) > 0 {
+		unsupported = append(unsupported, "outputSelector")
+	}
+	if sr.Affiliate != nil {
+		unsupported = append(unsupported, "affiliate")
+	}
+	if sr.BuyerPostalCode != "" {
+		unsupported = append(unsupported, "buyerPostalCode")
+	}
+	if len(unsupported) > 0 {
+		return "", fmt.Errorf("%s can't be expressed in query", strings.Join(unsupported, ", "))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// queryPrice trims insignificant zeros of price value
+func queryPrice(value string) string {
+	if strings.Contains(value, ".") {
+		value = strings.TrimSuffix(strings.TrimRight(value, "0"), ".")
+	}
+	return value
+}
+
+// queryConditionNames returns query names of condition values. Unknown IDs are kept as is.
+func queryConditionNames(values []string) ([]string, bool) {
+	var names []string
+	for _, v := range values {
+		name := v
+		for _, c := range queryConditions {
+			if string(c.condition) == v {
+				name = c.name
+				break
+			}
+		}
+		if name == v {
+			// condition names (WithItemFilterConditionName) can't be expressed
+			if _, err := strconv.Atoi(v); err != nil {
+				return nil, false
+			}
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// queryListingTypeNames returns query names of listing type values
+func queryListingTypeNames(values []string) ([]string, bool) {
+	var names []string
+	for _, v := range values {
+		found := false
+		for _, lt := range queryListingTypes {
+			if string(lt.listingType) == v {
+				names = append(names, lt.name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return names, true
+}
+
+// querySortOrderName returns query name of sort order
+func querySortOrderName(order SortOrderParameter) (string, bool) {
+	for _, so := range querySortOrders {
+		if so.order == order {
+			return so.name, true
+		}
+	}
+	return "", false
+}
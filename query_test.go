@@ -0,0 +1,73 @@
+package finding
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestService_ParseQuery(t *testing.T) {
+	r, err := NewService("").ParseQuery(`"lego 10179" -used price:100..900 cond:new,likenew seller:-badguy sort:price+ site:EBAY-DE ship:free`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `"lego 10179" -used`, r.Keywords)
+	assert.Equal(t, string(SortOrderPricePlusShippingLowest), r.SortOrder)
+	assert.Equal(t, GlobalIDEbayDE, r.globalID())
+	assert.Equal(t, []ServiceItemFilter{
+		{Name: string(ItemFilterCondition), Value: []string{string(ConditionNew), string(ConditionLikeNew)}},
+		{Name: string(ItemFilterExcludeSeller), Value: []string{"badguy"}},
+		{Name: string(ItemFilterFreeShippingOnly), Value: []string{"true"}},
+		{Name: string(ItemFilterMaxPrice), Value: []string{"900.00"}},
+		{Name: string(ItemFilterMinPrice), Value: []string{"100.00"}},
+	}, r.ItemFilters())
+
+	q, err := r.Query()
+	assert.NoError(t, err)
+	assert.Equal(t, `"lego 10179" -used price:100..900 cond:new,likenew seller:-badguy ship:free sort:price+ site:EBAY-DE`, q)
+	back, err := NewService("").ParseQuery(q)
+	if assert.NoError(t, err) {
+		assert.Equal(t, r.ItemFilters(), back.ItemFilters())
+		assert.Equal(t, r.RequestKeywords, back.RequestKeywords)
+		assert.Equal(t, r.RequestStandard.SortOrder, back.RequestStandard.SortOrder)
+	}
+}
+
+func TestService_ParseQueryErrors(t *testing.T) {
+	s := NewService("")
+	for query, want := range map[string]*QueryError{
+		`lego "star wars`:      {Offset: 5, Msg: "unterminated quote"},
+		`lego ""`:              {Offset: 5, Msg: "empty phrase"},
+		`"lego"x`:              {Offset: 6, Msg: "missing space after phrase"},
+		`lego price:`:          {Offset: 5, Msg: "empty price: value"},
+		`lego price:abc..5`:    {Offset: 11, Msg: `invalid price "abc"`},
+		`lego price:9..5`:      {Offset: 11, Msg: `price "9..5" has minimum above maximum`},
+		`lego price:5`:         {Offset: 11, Msg: `price "5" isn't a range`},
+		`lego cond:new,broken`: {Offset: 10, Msg: `unknown condition "broken"`},
+		`lego seller:a,,b`:     {Offset: 12, Msg: `empty value in list "a,,b"`},
+		`lego colour:red`:      {Offset: 12, Msg: `unknown qualifier "colour"`},
+		`lego site:EBAY-XX`:    {Offset: 10, Msg: `unknown site "EBAY-XX"`},
+		`lego sort:random`:     {Offset: 10, Msg: `unknown sort order "random"`},
+		`lego sel"ler`:         {Offset: 8, Msg: "unexpected quote"},
+	} {
+		_, err := s.ParseQuery(query)
+		var qe *QueryError
+		if assert.True(t, errors.As(err, &qe), query) {
+			assert.Equal(t, want, qe, query)
+		}
+	}
+}
+
+func TestAdvancedRequest_QueryUnsupported(t *testing.T) {
+	r := NewService("").NewAdvancedRequest()
+	r.WithKeywords("harry potter")
+	r.WithAspectFilter("Color", "Red")
+	r.WithItemFilterMinPriceWithCurrency(5, CurrencyIDEUR)
+	_, err := r.Query()
+	assert.EqualError(t, err, "MinPrice, aspectFilter can't be expressed in query")
+
+	r = NewService("").NewAdvancedRequest()
+	r.WithKeywords("time 10:30 note:x")
+	_, err = r.Query()
+	assert.EqualError(t, err, `keyword "note:x" can't be expressed in query`)
+}
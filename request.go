@@ -1,6 +1,7 @@
 package finding
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 )
@@ -20,27 +21,23 @@ type AdvancedRequest struct {
 // GetPage executes AdvancedRequest for page #
 // Valid pages # 1 - 100
 func (sr *AdvancedRequest) GetPage(page int) (AdvancedResponse, error) {
+	return sr.GetPageContext(context.Background(), page)
+}
+
+// GetPageContext executes AdvancedRequest for page # using ctx to cancel the call
+// Valid pages # 1 - 100
+func (sr *AdvancedRequest) GetPageContext(ctx context.Context, page int) (AdvancedResponse, error) {
 	if page < 1 {
 		page = 1
 	}
-	sr.WithPageNumber(page)
-	body, err := sr.getBody()
-	if err != nil {
-		return AdvancedResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
-	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
+	req := *sr
+	body, err := req.pageBody(ctx, page, req.Validate, req.getBody)
 	if err != nil {
-		return AdvancedResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return AdvancedResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
+		return AdvancedResponse{}, err
 	}
 	ar := AdvancedResponse{}
-	err = xml.Unmarshal(res.Body(), &ar)
-	if err != nil {
-		return AdvancedResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return ar, nil
+	err = sr.execute(ctx, body, &ar)
+	return ar, err
 }
 
 // Execute executes AdvancedRequest for the first page
@@ -48,15 +45,22 @@ func (sr *AdvancedRequest) Execute() (AdvancedResponse, error) {
 	return sr.GetPage(1)
 }
 
+// ExecuteContext executes AdvancedRequest for the first page using ctx to cancel the call
+func (sr *AdvancedRequest) ExecuteContext(ctx context.Context) (AdvancedResponse, error) {
+	return sr.GetPageContext(ctx, 1)
+}
+
 // GetBody return AdvancedRequest body as XML
 func (sr *AdvancedRequest) GetBody() ([]byte, error) {
-	sr.prepare()
-	return xml.MarshalIndent(sr, "", "  ")
+	req := *sr
+	req.prepare()
+	return xml.MarshalIndent(&req, "", "  ")
 }
 
 func (sr *AdvancedRequest) getBody() ([]byte, error) {
-	sr.prepare()
-	return xml.Marshal(sr)
+	req := *sr
+	req.prepare()
+	return xml.Marshal(&req)
 }
 
 /*
@@ -77,27 +81,23 @@ type ByCategoryRequest struct {
 // GetPage executes ByCategoryRequest for page #
 // Valid pages # 1 - 100
 func (sr *ByCategoryRequest) GetPage(page int) (ByCategoryResponse, error) {
+	return sr.GetPageContext(context.Background(), page)
+}
+
+// GetPageContext executes ByCategoryRequest for page # using ctx to cancel the call
+// Valid pages # 1 - 100
+func (sr *ByCategoryRequest) GetPageContext(ctx context.Context, page int) (ByCategoryResponse, error) {
 	if page < 1 {
 		page = 1
 	}
-	sr.WithPageNumber(page)
-	body, err := sr.getBody()
-	if err != nil {
-		return ByCategoryResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
-	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
+	req := *sr
+	body, err := req.pageBody(ctx, page, req.Validate, req.getBody)
 	if err != nil {
-		return ByCategoryResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return ByCategoryResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
+		return ByCategoryResponse{}, err
 	}
 	bcr := ByCategoryResponse{}
-	err = xml.Unmarshal(res.Body(), &bcr)
-	if err != nil {
-		return ByCategoryResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return bcr, nil
+	err = sr.execute(ctx, body, &bcr)
+	return bcr, err
 }
 
 // Execute executes ByCategoryRequest for the first page
@@ -105,15 +105,22 @@ func (sr *ByCategoryRequest) Execute() (ByCategoryResponse, error) {
 	return sr.GetPage(1)
 }
 
+// ExecuteContext executes ByCategoryRequest for the first page using ctx to cancel the call
+func (sr *ByCategoryRequest) ExecuteContext(ctx context.Context) (ByCategoryResponse, error) {
+	return sr.GetPageContext(ctx, 1)
+}
+
 // GetBody return ByCategoryRequest body as XML
 func (sr *ByCategoryRequest) GetBody() ([]byte, error) {
-	sr.prepare()
-	return xml.MarshalIndent(sr, "", "  ")
+	req := *sr
+	req.prepare()
+	return xml.MarshalIndent(&req, "", "  ")
 }
 
 func (sr *ByCategoryRequest) getBody() ([]byte, error) {
-	sr.prepare()
-	return xml.Marshal(sr)
+	req := *sr
+	req.prepare()
+	return xml.Marshal(&req)
 }
 
 /*
@@ -134,27 +141,23 @@ type ByKeywordsRequest struct {
 // GetPage executes ByKeywordsRequest for page #
 // Valid pages # 1 - 100
 func (sr *ByKeywordsRequest) GetPage(page int) (ByKeywordsResponse, error) {
+	return sr.GetPageContext(context.Background(), page)
+}
+
+// GetPageContext executes ByKeywordsRequest for page # using ctx to cancel the call
+// Valid pages # 1 - 100
+func (sr *ByKeywordsRequest) GetPageContext(ctx context.Context, page int) (ByKeywordsResponse, error) {
 	if page < 1 {
 		page = 1
 	}
-	sr.WithPageNumber(page)
-	body, err := sr.getBody()
-	if err != nil {
-		return ByKeywordsResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
-	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
+	req := *sr
+	body, err := req.pageBody(ctx, page, req.Validate, req.getBody)
 	if err != nil {
-		return ByKeywordsResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return ByKeywordsResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
+		return ByKeywordsResponse{}, err
 	}
 	bkr := ByKeywordsResponse{}
-	err = xml.Unmarshal(res.Body(), &bkr)
-	if err != nil {
-		return ByKeywordsResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return bkr, nil
+	err = sr.execute(ctx, body, &bkr)
+	return bkr, err
 }
 
 // Execute executes ByKeywordsRequest for the first page
@@ -162,15 +165,22 @@ func (sr *ByKeywordsRequest) Execute() (ByKeywordsResponse, error) {
 	return sr.GetPage(1)
 }
 
+// ExecuteContext executes ByKeywordsRequest for the first page using ctx to cancel the call
+func (sr *ByKeywordsRequest) ExecuteContext(ctx context.Context) (ByKeywordsResponse, error) {
+	return sr.GetPageContext(ctx, 1)
+}
+
 // GetBody return ByKeywordsRequest body as XML
 func (sr *ByKeywordsRequest) GetBody() ([]byte, error) {
-	sr.prepare()
-	return xml.MarshalIndent(sr, "", "  ")
+	req := *sr
+	req.prepare()
+	return xml.MarshalIndent(&req, "", "  ")
 }
 
 func (sr *ByKeywordsRequest) getBody() ([]byte, error) {
-	sr.prepare()
-	return xml.Marshal(sr)
+	req := *sr
+	req.prepare()
+	return xml.Marshal(&req)
 }
 
 /*
@@ -189,27 +199,23 @@ type ByProductRequest struct {
 // GetPage executes ByProductRequest for page #
 // Valid pages # 1 - 100
 func (sr *ByProductRequest) GetPage(page int) (ByProductResponse, error) {
+	return sr.GetPageContext(context.Background(), page)
+}
+
+// GetPageContext executes ByProductRequest for page # using ctx to cancel the call
+// Valid pages # 1 - 100
+func (sr *ByProductRequest) GetPageContext(ctx context.Context, page int) (ByProductResponse, error) {
 	if page < 1 {
 		page = 1
 	}
-	sr.WithPageNumber(page)
-	body, err := sr.getBody()
-	if err != nil {
-		return ByProductResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
-	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
+	req := *sr
+	body, err := req.pageBody(ctx, page, req.Validate, req.getBody)
 	if err != nil {
-		return ByProductResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return ByProductResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
+		return ByProductResponse{}, err
 	}
 	bpr := ByProductResponse{}
-	err = xml.Unmarshal(res.Body(), &bpr)
-	if err != nil {
-		return ByProductResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return bpr, nil
+	err = sr.execute(ctx, body, &bpr)
+	return bpr, err
 }
 
 // Execute executes ByProductRequest for the first page
@@ -217,15 +223,22 @@ func (sr *ByProductRequest) Execute() (ByProductResponse, error) {
 	return sr.GetPage(1)
 }
 
+// ExecuteContext executes ByProductRequest for the first page using ctx to cancel the call
+func (sr *ByProductRequest) ExecuteContext(ctx context.Context) (ByProductResponse, error) {
+	return sr.GetPageContext(ctx, 1)
+}
+
 // GetBody return ByProductRequest body as XML
 func (sr *ByProductRequest) GetBody() ([]byte, error) {
-	sr.prepare()
-	return xml.MarshalIndent(sr, "", "  ")
+	req := *sr
+	req.prepare()
+	return xml.MarshalIndent(&req, "", "  ")
 }
 
 func (sr *ByProductRequest) getBody() ([]byte, error) {
-	sr.prepare()
-	return xml.Marshal(sr)
+	req := *sr
+	req.prepare()
+	return xml.Marshal(&req)
 }
 
 /*
@@ -255,27 +268,23 @@ func (sr *InEbayStoresRequest) WithStoreName(name string) *InEbayStoresRequest {
 // GetPage executes InEbayStoresRequest for page #
 // Valid pages # 1 - 100
 func (sr *InEbayStoresRequest) GetPage(page int) (InEbayStoresResponse, error) {
+	return sr.GetPageContext(context.Background(), page)
+}
+
+// GetPageContext executes InEbayStoresRequest for page # using ctx to cancel the call
+// Valid pages # 1 - 100
+func (sr *InEbayStoresRequest) GetPageContext(ctx context.Context, page int) (InEbayStoresResponse, error) {
 	if page < 1 {
 		page = 1
 	}
-	sr.WithPageNumber(page)
-	body, err := sr.getBody()
-	if err != nil {
-		return InEbayStoresResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
-	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
+	req := *sr
+	body, err := req.pageBody(ctx, page, req.Validate, req.getBody)
 	if err != nil {
-		return InEbayStoresResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return InEbayStoresResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
+		return InEbayStoresResponse{}, err
 	}
 	iesr := InEbayStoresResponse{}
-	err = xml.Unmarshal(res.Body(), &iesr)
-	if err != nil {
-		return InEbayStoresResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return iesr, nil
+	err = sr.execute(ctx, body, &iesr)
+	return iesr, err
 }
 
 // Execute executes InEbayStoresRequest for the first page
@@ -283,15 +292,96 @@ func (sr *InEbayStoresRequest) Execute() (InEbayStoresResponse, error) {
 	return sr.GetPage(1)
 }
 
+// ExecuteContext executes InEbayStoresRequest for the first page using ctx to cancel the call
+func (sr *InEbayStoresRequest) ExecuteContext(ctx context.Context) (InEbayStoresResponse, error) {
+	return sr.GetPageContext(ctx, 1)
+}
+
 // GetBody return InEbayStoresRequest body as XML
 func (sr *InEbayStoresRequest) GetBody() ([]byte, error) {
-	sr.prepare()
-	return xml.MarshalIndent(sr, "", "  ")
+	req := *sr
+	req.prepare()
+	return xml.MarshalIndent(&req, "", "  ")
 }
 
 func (sr *InEbayStoresRequest) getBody() ([]byte, error) {
-	sr.prepare()
-	return xml.Marshal(sr)
+	req := *sr
+	req.prepare()
+	return xml.Marshal(&req)
+}
+
+/*
+==============================================================================
+*/
+
+// CompletedItemsRequest searches for items whose listings are completed and are no longer available for sale
+// by category (using categoryId), by keywords (using keywords), or a combination of the two.
+// Use WithItemFilterSoldItemsOnly to get sold items only.
+type CompletedItemsRequest struct {
+	XMLName xml.Name `xml:"http://www.ebay.com/marketplace/search/v1/services findCompletedItemsRequest" json:"-"`
+	RequestKeywords
+	RequestCategories
+	RequestAspectFilter
+	RequestItemFilter
+	RequestOutputSelector
+	RequestStandard
+}
+
+// WithSortOrder sorts the returned items according to a single specified sort order.
+// Default: BestMatch.
+// Sort orders not supported by findCompletedItems (WatchCountDecreaseSort) are ignored.
+func (sr *CompletedItemsRequest) WithSortOrder(order SortOrderParameter) *CompletedItemsRequest {
+	if _, ok := completedItemsSortOrders[order]; !ok {
+		sr.RequestBasic.inputs.record("sortOrder", "WithSortOrder", "%s isn't supported by findCompletedItems and is ignored", order)
+		return sr
+	}
+	sr.RequestStandard.WithSortOrder(order)
+	return sr
+}
+
+// GetPage executes CompletedItemsRequest for page #
+// Valid pages # 1 - 100
+func (sr *CompletedItemsRequest) GetPage(page int) (CompletedItemsResponse, error) {
+	return sr.GetPageContext(context.Background(), page)
+}
+
+// GetPageContext executes CompletedItemsRequest for page # using ctx to cancel the call
+// Valid pages # 1 - 100
+func (sr *CompletedItemsRequest) GetPageContext(ctx context.Context, page int) (CompletedItemsResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	req := *sr
+	body, err := req.pageBody(ctx, page, req.Validate, req.getBody)
+	if err != nil {
+		return CompletedItemsResponse{}, err
+	}
+	cir := CompletedItemsResponse{}
+	err = sr.execute(ctx, body, &cir)
+	return cir, err
+}
+
+// Execute executes CompletedItemsRequest for the first page
+func (sr *CompletedItemsRequest) Execute() (CompletedItemsResponse, error) {
+	return sr.GetPage(1)
+}
+
+// ExecuteContext executes CompletedItemsRequest for the first page using ctx to cancel the call
+func (sr *CompletedItemsRequest) ExecuteContext(ctx context.Context) (CompletedItemsResponse, error) {
+	return sr.GetPageContext(ctx, 1)
+}
+
+// GetBody return CompletedItemsRequest body as XML
+func (sr *CompletedItemsRequest) GetBody() ([]byte, error) {
+	req := *sr
+	req.prepare()
+	return xml.MarshalIndent(&req, "", "  ")
+}
+
+func (sr *CompletedItemsRequest) getBody() ([]byte, error) {
+	req := *sr
+	req.prepare()
+	return xml.Marshal(&req)
 }
 
 /*
@@ -314,23 +404,23 @@ func (sr *GetHistogramsRequest) WithCategory(category string) *GetHistogramsRequ
 
 // Execute executes GetHistogramsRequest
 func (sr *GetHistogramsRequest) Execute() (GetHistogramsResponse, error) {
+	return sr.ExecuteContext(context.Background())
+}
+
+// ExecuteContext executes GetHistogramsRequest using ctx to cancel the call
+func (sr *GetHistogramsRequest) ExecuteContext(ctx context.Context) (GetHistogramsResponse, error) {
+	if sr.validationEnabled() {
+		if err := sr.Validate(); err != nil {
+			return GetHistogramsResponse{}, err
+		}
+	}
 	body, err := sr.getBody()
 	if err != nil {
 		return GetHistogramsResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
 	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
-	if err != nil {
-		return GetHistogramsResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return GetHistogramsResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
-	}
 	ghr := GetHistogramsResponse{}
-	err = xml.Unmarshal(res.Body(), &ghr)
-	if err != nil {
-		return GetHistogramsResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return ghr, nil
+	err = sr.execute(ctx, body, &ghr)
+	return ghr, err
 }
 
 // GetBody return GetHistogramsRequest body as XML
@@ -356,23 +446,23 @@ type GetKeywordsRecommendationRequest struct {
 
 // Execute executes GetKeywordsRecommendationRequest
 func (sr *GetKeywordsRecommendationRequest) Execute() (GetKeywordsRecommendationResponse, error) {
+	return sr.ExecuteContext(context.Background())
+}
+
+// ExecuteContext executes GetKeywordsRecommendationRequest using ctx to cancel the call
+func (sr *GetKeywordsRecommendationRequest) ExecuteContext(ctx context.Context) (GetKeywordsRecommendationResponse, error) {
+	if sr.validationEnabled() {
+		if err := sr.Validate(); err != nil {
+			return GetKeywordsRecommendationResponse{}, err
+		}
+	}
 	body, err := sr.getBody()
 	if err != nil {
 		return GetKeywordsRecommendationResponse{}, fmt.Errorf("unable to serialize req body: %w", err)
 	}
-	res, err := sr.Client.R().SetBody(body).Post(sr.URL)
-	if err != nil {
-		return GetKeywordsRecommendationResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return GetKeywordsRecommendationResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
-	}
 	gkrr := GetKeywordsRecommendationResponse{}
-	err = xml.Unmarshal(res.Body(), &gkrr)
-	if err != nil {
-		return GetKeywordsRecommendationResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return gkrr, nil
+	err = sr.execute(ctx, body, &gkrr)
+	return gkrr, err
 }
 
 // GetBody return GetKeywordsRecommendationRequest body as XML
@@ -398,19 +488,14 @@ type GetVersionRequest struct {
 
 // Execute executes GetVersionRequest
 func (sr *GetVersionRequest) Execute() (GetVersionResponse, error) {
-	res, err := sr.Client.R().Post(sr.URL)
-	if err != nil {
-		return GetVersionResponse{}, fmt.Errorf("sending req: %w", err)
-	}
-	if res.StatusCode() != 200 {
-		return GetVersionResponse{}, fmt.Errorf("status code %d: %s", res.StatusCode(), res.String())
-	}
+	return sr.ExecuteContext(context.Background())
+}
+
+// ExecuteContext executes GetVersionRequest using ctx to cancel the call
+func (sr *GetVersionRequest) ExecuteContext(ctx context.Context) (GetVersionResponse, error) {
 	gvr := GetVersionResponse{}
-	err = xml.Unmarshal(res.Body(), &gvr)
-	if err != nil {
-		return GetVersionResponse{}, fmt.Errorf("parsing response body: %w", err)
-	}
-	return gvr, nil
+	err := sr.execute(ctx, nil, &gvr)
+	return gvr, err
 }
 
 // GetBody return GetVersionRequest body as XML
@@ -1,14 +1,153 @@
 package finding
 
 import (
-	"github.com/go-resty/resty/v2"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
 	"strconv"
+	"time"
+	"unicode/utf8"
 )
 
 // RequestBasic is used for requests without pages
 type RequestBasic struct {
-	URL    string        `json:"-" xml:"-"`
-	Client *resty.Client `json:"-" xml:"-"`
+	URL    string `json:"-" xml:"-"`
+	Client Doer   `json:"-" xml:"-"`
+
+	service   *Service
+	operation EbayOperation
+	inputs    *inputRecorder
+	site      GlobalID
+}
+
+// WithGlobalID changes site for search of this request only. Empty globalID resets the site to the one of Service.
+func (sr *RequestBasic) WithGlobalID(globalID GlobalID) *RequestBasic {
+	sr.site = globalID
+	return sr
+}
+
+// globalID returns the site of the request
+func (sr *RequestBasic) globalID() GlobalID {
+	if sr.site != "" {
+		return sr.site
+	}
+	if sr.service == nil {
+		return ""
+	}
+	return GlobalID(sr.service.globalID)
+}
+
+// ackResponse is a Finding API response with standard output fields
+type ackResponse interface {
+	apiError() error
+}
+
+// execute sends req body to the Finding API and decodes response body into v.
+// Failed calls are retried according to RetryPolicy of the Service.
+// Every attempt waits for the rate limiter and is counted in the daily quota of the Service.
+// If ctx is done before the response is received, returned error wraps ctx.Err(),
+// so context.Canceled and context.DeadlineExceeded can be checked with errors.Is.
+// If eBay acknowledges the call with Failure or PartialFailure, v is decoded and *APIErrors is returned.
+// Requests with inputs recorded in strict mode aren't sent.
+func (sr *RequestBasic) execute(ctx context.Context, body []byte, v ackResponse) error {
+	if err := sr.Err(); err != nil {
+		return err
+	}
+	var policy RetryPolicy
+	if sr.service != nil {
+		policy = sr.service.retryPolicy
+	}
+	for attempt := 1; ; attempt++ {
+		err := sr.executeOnce(ctx, body, v)
+		var backoff time.Duration
+		retry := err != nil && attempt < policy.MaxAttempts && IsRetriable(err)
+		if retry {
+			backoff = policy.backoff(attempt)
+		}
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(RetryAttempt{Attempt: attempt, Err: err, Backoff: backoff})
+		}
+		if !retry {
+			return err
+		}
+		resetResponse(v)
+		if err := sleepContext(ctx, backoff); err != nil {
+			return fmt.Errorf("waiting to retry: %w", err)
+		}
+	}
+}
+
+// executeOnce makes single attempt of the call
+func (sr *RequestBasic) executeOnce(ctx context.Context, body []byte, v ackResponse) error {
+	if sr.service == nil || sr.Client == nil {
+		return errors.New("req isn't bound to Service: create it with Service constructors")
+	}
+	if err := sr.service.acquire(ctx); err != nil {
+		return err
+	}
+	attemptCtx := ctx
+	if sr.service.timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, sr.service.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(attemptCtx, http.MethodPost, sr.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating req: %w", err)
+	}
+	req.Header = sr.service.headers(sr.operation, sr.globalID())
+	res, err := sr.Client.Do(req)
+	if err == nil {
+		defer res.Body.Close()
+		var resBody []byte
+		resBody, err = io.ReadAll(res.Body)
+		if err == nil {
+			return sr.decode(res.StatusCode, resBody, v)
+		}
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("sending req: %w", ctxErr)
+	}
+	return &transportError{err: err}
+}
+
+// decode decodes response body of the call into v
+func (sr *RequestBasic) decode(statusCode int, body []byte, v ackResponse) error {
+	if statusCode != http.StatusOK {
+		return newHTTPError(statusCode, body)
+	}
+	err := xml.Unmarshal(body, v)
+	if err != nil {
+		resetResponse(v)
+		return fmt.Errorf("parsing response body: %w", err)
+	}
+	return v.apiError()
+}
+
+// newHTTPError creates HTTPError with errors reported by eBay in the response body
+func newHTTPError(statusCode int, body []byte) *HTTPError {
+	httpErr := &HTTPError{StatusCode: statusCode, Body: body}
+	var em struct {
+		Errors []Error `xml:"error"`
+	}
+	if err := xml.Unmarshal(body, &em); err == nil && len(em.Errors) > 0 {
+		httpErr.APIErrors = &APIErrors{Ack: AckFailure}
+		for _, e := range em.Errors {
+			httpErr.APIErrors.Errors = append(httpErr.APIErrors.Errors, newAPIError(e))
+		}
+	}
+	return httpErr
+}
+
+// resetResponse sets response v to its zero value
+func resetResponse(v ackResponse) {
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
 }
 
 /*
@@ -23,6 +162,35 @@ type RequestStandard struct {
 	BuyerPostalCode string                 `json:"buyerPostalCode,omitempty" xml:"buyerPostalCode,omitempty"`
 
 	RequestBasic
+
+	// validationTime is the time EndTimeFrom and other time item filters are validated at, zero means now
+	validationTime time.Time
+}
+
+// pageBody validates and serializes the copy of find* request for page: sr is RequestStandard of the copy,
+// validate and getBody are the copy's methods, so the request isn't changed and can serve concurrent calls.
+// Time item filters are validated at the time pinned in ctx by Pager, FetchAll and Crawl (see withValidationTime).
+// In strict mode a changed page is returned as error of the call and isn't recorded in the request.
+func (sr *RequestStandard) pageBody(ctx context.Context, page int, validate func() error, getBody func() ([]byte, error)) ([]byte, error) {
+	if sr.validationEnabled() {
+		sr.validationTime = validationTime(ctx)
+		if err := validate(); err != nil {
+			return nil, err
+		}
+	}
+	inputs := sr.inputs
+	sr.inputs = inputs.call()
+	sr.WithPageNumber(page)
+	err := sr.inputs.err()
+	sr.inputs = inputs
+	if err != nil {
+		return nil, err
+	}
+	body, err := getBody()
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize req body: %w", err)
+	}
+	return body, nil
 }
 
 // ServiceAffiliate represents Affiliate Program
@@ -51,10 +219,13 @@ type ServicePaginationInput struct {
 // WithPageLimit sets page limit to list of items
 //  Min: 1. Max: 100. Default: 100.
 func (sr *RequestStandard) WithPageLimit(limit int) *RequestStandard {
+	sr.inputs.forget("entriesPerPage")
 	if limit < 1 {
+		sr.inputs.record("entriesPerPage", "WithPageLimit", "%d is changed to 1", limit)
 		limit = 1
 	} else {
 		if limit > 100 {
+			sr.inputs.record("entriesPerPage", "WithPageLimit", "%d is changed to 100", limit)
 			limit = 100
 		}
 	}
@@ -65,10 +236,13 @@ func (sr *RequestStandard) WithPageLimit(limit int) *RequestStandard {
 // WithPageNumber sets page to get
 //  Min: 1. Max: 100. Default: 100.
 func (sr *RequestStandard) WithPageNumber(page int) *RequestStandard {
+	sr.inputs.forget("pageNumber")
 	if page < 1 {
+		sr.inputs.record("pageNumber", "WithPageNumber", "%d is changed to 1", page)
 		page = 1
 	} else {
 		if page > 100 {
+			sr.inputs.record("pageNumber", "WithPageNumber", "%d is changed to 100", page)
 			page = 100
 		}
 	}
@@ -79,6 +253,7 @@ func (sr *RequestStandard) WithPageNumber(page int) *RequestStandard {
 // WithSortOrder sorts the returned items according to a single specified sort order.
 // Default: BestMatch.
 func (sr *RequestStandard) WithSortOrder(order SortOrderParameter) *RequestStandard {
+	sr.inputs.forget("sortOrder")
 	sr.SortOrder = string(order)
 	return sr
 }
@@ -96,6 +271,8 @@ func (sr *RequestStandard) WithBuyerPostalCode(code string) *RequestStandard {
 // RequestAspectFilter represents AspectFilter part of ebay Finding requests
 type RequestAspectFilter struct {
 	AspectFilter []ServiceAspectFilter `json:"aspectFilter,omitempty" xml:"aspectFilter,omitempty"` // removed
+
+	inputs *inputRecorder
 }
 
 // ServiceAspectFilter represents AspectFilter
@@ -107,9 +284,11 @@ type ServiceAspectFilter struct {
 // WithAspectFilter adds AspectFilter to requests
 func (sr *RequestAspectFilter) WithAspectFilter(aspectName string, aspectValues ...string) *RequestAspectFilter {
 	if len(aspectValues) < 1 {
+		sr.inputs.record("aspectFilter", "WithAspectFilter", "aspect %q without values is ignored", aspectName)
 		return sr
 	}
 	if aspectName == "" {
+		sr.inputs.record("aspectFilter", "WithAspectFilter", "aspect without name is ignored")
 		return sr
 	}
 	sr.AspectFilter = append(sr.AspectFilter, ServiceAspectFilter{aspectName, aspectValues})
@@ -123,12 +302,22 @@ func (sr *RequestAspectFilter) WithAspectFilter(aspectName string, aspectValues
 // RequestCategories represents filter by categories in ebay Finding requests
 type RequestCategories struct {
 	CategoryID []string `json:"categoryId,omitempty" xml:"categoryId,omitempty"`
+
+	inputs *inputRecorder
+}
+
+// ClearCategories removes all categories
+func (sr *RequestCategories) ClearCategories() *RequestCategories {
+	sr.inputs.forget("categoryId")
+	sr.CategoryID = nil
+	return sr
 }
 
 // WithCategoryIDInt adds category for searching (up to 3 categories)
 //  4th and next categoryIDs will be skipped silently
 func (sr *RequestCategories) WithCategoryIDInt(categoryID int) *RequestCategories {
 	if len(sr.CategoryID) >= 3 {
+		sr.inputs.record("categoryId", "WithCategoryIDInt", "category %d is skipped: up to 3 categories", categoryID)
 		return sr
 	}
 	sr.CategoryID = append(sr.CategoryID, strconv.Itoa(categoryID))
@@ -139,6 +328,7 @@ func (sr *RequestCategories) WithCategoryIDInt(categoryID int) *RequestCategorie
 //  4th and next categoryIDs will be skipped silently
 func (sr *RequestCategories) WithCategoryID(categoryID string) *RequestCategories {
 	if len(sr.CategoryID) >= 3 {
+		sr.inputs.record("categoryId", "WithCategoryID", "category %s is skipped: up to 3 categories", categoryID)
 		return sr
 	}
 	sr.CategoryID = append(sr.CategoryID, categoryID)
@@ -148,8 +338,9 @@ func (sr *RequestCategories) WithCategoryID(categoryID string) *RequestCategorie
 // WithCategoriesID adds categories for searching (up to 3 categories)
 //  4th and next categoryIDs will be skipped silently
 func (sr *RequestCategories) WithCategoriesID(categoriesID ...string) *RequestCategories {
-	for _, categoryID := range categoriesID {
+	for i, categoryID := range categoriesID {
 		if len(sr.CategoryID) >= 3 {
+			sr.inputs.record("categoryId", "WithCategoriesID", "categories %v are skipped: up to 3 categories", categoriesID[i:])
 			return sr
 		}
 		sr.CategoryID = append(sr.CategoryID, categoryID)
@@ -180,19 +371,24 @@ func (sr *RequestDescriptionSearch) WithDescriptionSearch(ds bool) *RequestDescr
 // RequestKeywords represents filter by keywords in ebay Finding requests
 type RequestKeywords struct {
 	Keywords string `json:"keywords,omitempty" xml:"keywords,omitempty"`
+
+	inputs *inputRecorder
 }
 
 // WithKeywords adds keywords for searching
-// Max length: 350. The maximum length for a single word is 98. Min length: 2.
-// Key longer than 350 symbols will be trimmed. Key shorter 2 (0 < n < 2) symbols won't change Keywords field.
-// Empty string removes Keywords field.
+// Max length: 350. The maximum length for a single word is 98. Min length: 2. Lengths are in characters.
+// Key longer than 350 characters will be trimmed. Key shorter 2 (0 < n < 2) characters won't change Keywords field.
+// Empty string removes Keywords field. Use Keywords to build key with eBay syntax.
 func (sr *RequestKeywords) WithKeywords(key string) *RequestKeywords {
-	if len(key) > 350 {
-		key = key[:350]
-	} else {
-		if len(key) == 1 {
-			return sr
-		}
+	n := utf8.RuneCountInString(key)
+	if n == 1 {
+		sr.inputs.record("keywords", "WithKeywords", "1-character keywords %q are ignored", key)
+		return sr
+	}
+	sr.inputs.forget("keywords")
+	if n > KeywordsMaxLength {
+		sr.inputs.record("keywords", "WithKeywords", "keywords are truncated to %d characters", KeywordsMaxLength)
+		key = truncateRunes(key, KeywordsMaxLength)
 	}
 	sr.Keywords = key
 	return sr
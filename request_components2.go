@@ -1,13 +1,17 @@
 package finding
 
 import (
+	"sort"
 	"strconv"
+	"time"
 )
 
 // RequestItemFilter works with ItemFilter ebay entity
 type RequestItemFilter struct {
 	ItemFilterMap map[ItemFilterParameter]ServiceItemFilter `json:"-" xml:"-"`
 	ItemFilter    []ServiceItemFilter                       `json:"itemFilter,omitempty" xml:"itemFilter,omitempty"`
+
+	inputs *inputRecorder
 }
 
 // ServiceItemFilter represents ItemFilter unit
@@ -23,24 +27,55 @@ func (sr *RequestItemFilter) Initialize() {
 	sr.ItemFilterMap = make(map[ItemFilterParameter]ServiceItemFilter)
 }
 
-// Reload refreshes itemFilter
+// Reload refreshes itemFilter.
+// ItemFilter is rebuilt from ItemFilterMap on every serialization, so calling Reload isn't needed anymore.
 func (sr *RequestItemFilter) Reload() {
 	sr.ItemFilter = nil
 }
 
+// RemoveItemFilter removes ItemFilter with given name
+func (sr *RequestItemFilter) RemoveItemFilter(ifp ItemFilterParameter) *RequestItemFilter {
+	sr.inputs.forget(string(ifp))
+	delete(sr.ItemFilterMap, ifp)
+	sr.ItemFilter = nil
+	return sr
+}
+
+// ClearItemFilters removes all ItemFilters
+func (sr *RequestItemFilter) ClearItemFilters() *RequestItemFilter {
+	for ifp := range itemFilterValueLimits {
+		sr.inputs.forget(string(ifp))
+	}
+	sr.Initialize()
+	sr.ItemFilter = nil
+	return sr
+}
+
+// ItemFilters returns copies of ItemFilters sorted by name in the order they are sent
+func (sr *RequestItemFilter) ItemFilters() []ServiceItemFilter {
+	if len(sr.ItemFilterMap) == 0 {
+		filters := make([]ServiceItemFilter, 0, len(sr.ItemFilter))
+		for _, f := range sr.ItemFilter {
+			filters = append(filters, f.clone())
+		}
+		return filters
+	}
+	filters := make([]ServiceItemFilter, 0, len(sr.ItemFilterMap))
+	for _, f := range sr.ItemFilterMap {
+		filters = append(filters, f.clone())
+	}
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Name < filters[j].Name })
+	return filters
+}
+
 // prepares ServiceItemFilter from changing ItemFilterMap. Executed every request call or getting req body.
+// ItemFilter is rebuilt every time in order of filter names, so the body is the same for the same filters.
+// If ItemFilterMap is empty, ItemFilter set directly is kept.
 func (sr *RequestItemFilter) prepare() {
 	if len(sr.ItemFilterMap) == 0 {
 		return
 	}
-	if len(sr.ItemFilter) != 0 {
-		return
-	}
-	var filter []ServiceItemFilter
-	for _, val := range sr.ItemFilterMap {
-		filter = append(filter, val)
-	}
-	sr.ItemFilter = filter
+	sr.ItemFilter = sr.ItemFilters()
 }
 
 // creates ItemFilter record with just Name
@@ -50,26 +85,30 @@ func (sr *RequestItemFilter) prepareIFMap(ifp ItemFilterParameter) {
 	}
 }
 
-// adds values to ItemFilter if accepted multiple values in this ItemFilter
+// adds values to ItemFilter if accepted multiple values in this ItemFilter.
+// Values keep the order they are added in, duplicates are skipped.
+// In strict mode only values skipped by the last call are recorded.
 func (sr *RequestItemFilter) addIFValues(ifp ItemFilterParameter, limit int, values ...string) {
 	if len(values) == 0 {
 		return
 	}
+	sr.inputs.forget(string(ifp))
 	sr.prepareIFMap(ifp)
 	oldValues := sr.ItemFilterMap[ifp].Value
+	newValues := make([]string, 0, len(oldValues)+len(values))
 	valuesMap := make(map[string]struct{})
-	for _, v := range oldValues {
-		valuesMap[v] = struct{}{}
-	}
-	for _, value := range values {
-		if len(valuesMap) >= limit {
-			break
+	for _, vs := range [][]string{oldValues, values} {
+		for _, v := range vs {
+			if _, ok := valuesMap[v]; ok {
+				continue
+			}
+			if len(newValues) >= limit {
+				sr.inputs.record(string(ifp), "WithItemFilter"+string(ifp), "value %q is skipped: up to %d values", v, limit)
+				continue
+			}
+			valuesMap[v] = struct{}{}
+			newValues = append(newValues, v)
 		}
-		valuesMap[value] = struct{}{}
-	}
-	newValues := make([]string, 0, len(valuesMap))
-	for k := range valuesMap {
-		newValues = append(newValues, k)
 	}
 	sr.ItemFilterMap[ifp] = ServiceItemFilter{
 		Name:  string(ifp),
@@ -79,6 +118,7 @@ func (sr *RequestItemFilter) addIFValues(ifp ItemFilterParameter, limit int, val
 
 // creates of updates value for this single-value ItemFilter
 func (sr *RequestItemFilter) updateIFValue(ifp ItemFilterParameter, value string) {
+	sr.inputs.forget(string(ifp))
 	sr.prepareIFMap(ifp)
 	sr.ItemFilterMap[ifp] = ServiceItemFilter{
 		Name:  string(ifp),
@@ -88,6 +128,7 @@ func (sr *RequestItemFilter) updateIFValue(ifp ItemFilterParameter, value string
 
 // creates or updates value with parameters for this single-value ItemFilter
 func (sr *RequestItemFilter) updateIFValueWithParameter(ifp ItemFilterParameter, paramName string, paramValue string, value string) {
+	sr.inputs.forget(string(ifp))
 	sr.prepareIFMap(ifp)
 	sr.ItemFilterMap[ifp] = ServiceItemFilter{
 		Name:       string(ifp),
@@ -207,7 +248,8 @@ func (sr *RequestItemFilter) WithItemFilterReturnsAcceptedOnly(b bool) *RequestI
 }
 
 // WithItemFilterSoldItemsOnly adds SoldItemsOnly ItemFilter
-// Reserved for future use. If true, excludes all completed items which are not ended by being sold.
+// If true, excludes all completed items which are not ended by being sold.
+// Applicable to findCompletedItems (CompletedItemsRequest) only.
 func (sr *RequestItemFilter) WithItemFilterSoldItemsOnly(b bool) *RequestItemFilter {
 	sr.updateIFValue(ItemFilterSoldItemsOnly, strconv.FormatBool(b))
 	return sr
@@ -257,6 +299,7 @@ func (sr *RequestItemFilter) WithItemFilterExpeditedShippingType(shipping ItemFi
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterFeedbackScoreMax(score int) *RequestItemFilter {
 	if score < 0 {
+		sr.inputs.record(string(ItemFilterFeedbackScoreMax), "WithItemFilterFeedbackScoreMax", "%d below 0 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterFeedbackScoreMax, strconv.Itoa(score))
@@ -271,6 +314,7 @@ func (sr *RequestItemFilter) WithItemFilterFeedbackScoreMax(score int) *RequestI
 //  Values below are 0 ignored.
 func (sr *RequestItemFilter) WithItemFilterFeedbackScoreMin(score int) *RequestItemFilter {
 	if score < 0 {
+		sr.inputs.record(string(ItemFilterFeedbackScoreMin), "WithItemFilterFeedbackScoreMin", "%d below 0 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterFeedbackScoreMin, strconv.Itoa(score))
@@ -293,6 +337,7 @@ func (sr *RequestItemFilter) WithItemFilterListedIn(globalID GlobalID) *RequestI
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMaxBids(score int) *RequestItemFilter {
 	if score < 0 {
+		sr.inputs.record(string(ItemFilterMaxBids), "WithItemFilterMaxBids", "%d below 0 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMaxBids, strconv.Itoa(score))
@@ -305,6 +350,7 @@ func (sr *RequestItemFilter) WithItemFilterMaxBids(score int) *RequestItemFilter
 //  Values below 5 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMaxDistance(score int) *RequestItemFilter {
 	if score < 5 {
+		sr.inputs.record(string(ItemFilterMaxDistance), "WithItemFilterMaxDistance", "%d below 5 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMaxDistance, strconv.Itoa(score))
@@ -320,6 +366,7 @@ func (sr *RequestItemFilter) WithItemFilterMaxDistance(score int) *RequestItemFi
 //  Values below 1 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMaxHandlingTime(score int) *RequestItemFilter {
 	if score < 1 {
+		sr.inputs.record(string(ItemFilterMaxHandlingTime), "WithItemFilterMaxHandlingTime", "%d below 1 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMaxHandlingTime, strconv.Itoa(score))
@@ -333,6 +380,7 @@ func (sr *RequestItemFilter) WithItemFilterMaxHandlingTime(score int) *RequestIt
 //  Values below 1 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMaxQuantity(score int) *RequestItemFilter {
 	if score < 1 {
+		sr.inputs.record(string(ItemFilterMaxQuantity), "WithItemFilterMaxQuantity", "%d below 1 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMaxQuantity, strconv.Itoa(score))
@@ -346,6 +394,7 @@ func (sr *RequestItemFilter) WithItemFilterMaxQuantity(score int) *RequestItemFi
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMinBids(score int) *RequestItemFilter {
 	if score < 0 {
+		sr.inputs.record(string(ItemFilterMinBids), "WithItemFilterMinBids", "%d below 0 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMinBids, strconv.Itoa(score))
@@ -359,6 +408,7 @@ func (sr *RequestItemFilter) WithItemFilterMinBids(score int) *RequestItemFilter
 //  Values below 1 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMinQuantity(score int) *RequestItemFilter {
 	if score < 1 {
+		sr.inputs.record(string(ItemFilterMinQuantity), "WithItemFilterMinQuantity", "%d below 1 is ignored", score)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMinQuantity, strconv.Itoa(score))
@@ -478,6 +528,7 @@ func (sr *RequestItemFilter) WithItemFilterLocatedIn(codes ...string) *RequestIt
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMaxPrice(price float64) *RequestItemFilter {
 	if price < 0 {
+		sr.inputs.record(string(ItemFilterMaxPrice), "WithItemFilterMaxPrice", "%.2f below 0 is ignored", price)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMaxPrice, strconv.FormatFloat(price, 'f', 2, 64))
@@ -490,6 +541,7 @@ func (sr *RequestItemFilter) WithItemFilterMaxPrice(price float64) *RequestItemF
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMaxPriceWithCurrency(price float64, currency ItemFilterCurrencyIDOption) *RequestItemFilter {
 	if price < 0 {
+		sr.inputs.record(string(ItemFilterMaxPrice), "WithItemFilterMaxPriceWithCurrency", "%.2f below 0 is ignored", price)
 		return sr
 	}
 	sr.updateIFValueWithParameter(ItemFilterMaxPrice, "Currency", string(currency), strconv.FormatFloat(price, 'f', 2, 64))
@@ -502,6 +554,7 @@ func (sr *RequestItemFilter) WithItemFilterMaxPriceWithCurrency(price float64, c
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMinPrice(price float64) *RequestItemFilter {
 	if price < 0 {
+		sr.inputs.record(string(ItemFilterMinPrice), "WithItemFilterMinPrice", "%.2f below 0 is ignored", price)
 		return sr
 	}
 	sr.updateIFValue(ItemFilterMinPrice, strconv.FormatFloat(price, 'f', 2, 64))
@@ -514,14 +567,49 @@ func (sr *RequestItemFilter) WithItemFilterMinPrice(price float64) *RequestItemF
 //  Values below 0 are ignored.
 func (sr *RequestItemFilter) WithItemFilterMinPriceWithCurrency(price float64, currency ItemFilterCurrencyIDOption) *RequestItemFilter {
 	if price < 0 {
+		sr.inputs.record(string(ItemFilterMinPrice), "WithItemFilterMinPriceWithCurrency", "%.2f below 0 is ignored", price)
 		return sr
 	}
 	sr.updateIFValueWithParameter(ItemFilterMinPrice, "Currency", string(currency), strconv.FormatFloat(price, 'f', 2, 64))
 	return sr
 }
 
+// WithItemFilterMaxPriceMoney adds MaxPrice ItemFilter (see WithItemFilterMaxPrice)
+// Price without currency is in the currency of the site.
+//  Values below 0 and currencies other than ItemFilterCurrencyIDOption are ignored.
+func (sr *RequestItemFilter) WithItemFilterMaxPriceMoney(price Money) *RequestItemFilter {
+	sr.updatePriceMoney("WithItemFilterMaxPriceMoney", ItemFilterMaxPrice, price)
+	return sr
+}
+
+// WithItemFilterMinPriceMoney adds MinPrice ItemFilter (see WithItemFilterMinPrice)
+// Price without currency is in the currency of the site.
+//  Values below 0 and currencies other than ItemFilterCurrencyIDOption are ignored.
+func (sr *RequestItemFilter) WithItemFilterMinPriceMoney(price Money) *RequestItemFilter {
+	sr.updatePriceMoney("WithItemFilterMinPriceMoney", ItemFilterMinPrice, price)
+	return sr
+}
+
+// updatePriceMoney sets price item filter ifp with the currency of price
+func (sr *RequestItemFilter) updatePriceMoney(builder string, ifp ItemFilterParameter, price Money) {
+	if price.Minor < 0 {
+		sr.inputs.record(string(ifp), builder, "%s below 0 is ignored", price)
+		return
+	}
+	if price.Currency == "" {
+		sr.updateIFValue(ifp, price.Decimal())
+		return
+	}
+	if _, ok := itemFilterCurrencies[ItemFilterCurrencyIDOption(price.Currency)]; !ok {
+		sr.inputs.record(string(ifp), builder, "currency %q isn't supported", price.Currency)
+		return
+	}
+	sr.updateIFValueWithParameter(ifp, "Currency", price.Currency, price.Decimal())
+}
+
 // WithItemFilterEndTimeFrom adds EndTimeFrom ItemFilter
 // Limits the results to items ending on or after the specified time. Specify a time in the future.
+// The datetime is sent as is, use WithItemFilterEndTimeFromTime to send time.Time.
 func (sr *RequestItemFilter) WithItemFilterEndTimeFrom(datetime string) *RequestItemFilter {
 	sr.updateIFValue(ItemFilterEndTimeFrom, datetime)
 	return sr
@@ -529,6 +617,7 @@ func (sr *RequestItemFilter) WithItemFilterEndTimeFrom(datetime string) *Request
 
 // WithItemFilterEndTimeTo adds EndTimeTo ItemFilter
 // Limits the results to items ending on or before the specified time. Specify a time in the future.
+// The datetime is sent as is, use WithItemFilterEndTimeToTime to send time.Time.
 func (sr *RequestItemFilter) WithItemFilterEndTimeTo(datetime string) *RequestItemFilter {
 	sr.updateIFValue(ItemFilterEndTimeTo, datetime)
 	return sr
@@ -537,13 +626,16 @@ func (sr *RequestItemFilter) WithItemFilterEndTimeTo(datetime string) *RequestIt
 // WithItemFilterModTimeFrom adds ModTimeFrom ItemFilter
 // Limits the results to active items whose status has changed since the specified time.
 // Specify a time in the past. Time must be in GMT.
+// The datetime is sent as is, use WithItemFilterModTimeFromTime to send time.Time.
 func (sr *RequestItemFilter) WithItemFilterModTimeFrom(datetime string) *RequestItemFilter {
 	sr.updateIFValue(ItemFilterModTimeFrom, datetime)
 	return sr
 }
 
 // WithItemFilterStartTimeFrom adds StartTimeFrom ItemFilter
-// Limits the results to items started on or after the specified time. Specify a time in the future.
+// Limits the results to items started on or after the specified time. Unlike EndTimeFrom, the time can be in the past
+// (see WithItemFilterStartedWithin).
+// The datetime is sent as is, use WithItemFilterStartTimeFromTime to send time.Time.
 func (sr *RequestItemFilter) WithItemFilterStartTimeFrom(datetime string) *RequestItemFilter {
 	sr.updateIFValue(ItemFilterStartTimeFrom, datetime)
 	return sr
@@ -551,7 +643,65 @@ func (sr *RequestItemFilter) WithItemFilterStartTimeFrom(datetime string) *Reque
 
 // WithItemFilterStartTimeTo adds StartTimeTo ItemFilter
 // Limits the results to items started on or before the specified time. Specify a time in the future.
+// The datetime is sent as is, use WithItemFilterStartTimeToTime to send time.Time.
 func (sr *RequestItemFilter) WithItemFilterStartTimeTo(datetime string) *RequestItemFilter {
 	sr.updateIFValue(ItemFilterStartTimeTo, datetime)
 	return sr
 }
+
+// WithItemFilterEndTimeFromTime adds EndTimeFrom ItemFilter (see WithItemFilterEndTimeFrom)
+// The time is converted to UTC eBay format.
+func (sr *RequestItemFilter) WithItemFilterEndTimeFromTime(t time.Time) *RequestItemFilter {
+	sr.updateIFValue(ItemFilterEndTimeFrom, ToEbayDateTime(t))
+	return sr
+}
+
+// WithItemFilterEndTimeToTime adds EndTimeTo ItemFilter (see WithItemFilterEndTimeTo)
+// The time is converted to UTC eBay format.
+func (sr *RequestItemFilter) WithItemFilterEndTimeToTime(t time.Time) *RequestItemFilter {
+	sr.updateIFValue(ItemFilterEndTimeTo, ToEbayDateTime(t))
+	return sr
+}
+
+// WithItemFilterModTimeFromTime adds ModTimeFrom ItemFilter (see WithItemFilterModTimeFrom)
+// The time is converted to UTC eBay format.
+func (sr *RequestItemFilter) WithItemFilterModTimeFromTime(t time.Time) *RequestItemFilter {
+	sr.updateIFValue(ItemFilterModTimeFrom, ToEbayDateTime(t))
+	return sr
+}
+
+// WithItemFilterStartTimeFromTime adds StartTimeFrom ItemFilter (see WithItemFilterStartTimeFrom)
+// The time is converted to UTC eBay format.
+func (sr *RequestItemFilter) WithItemFilterStartTimeFromTime(t time.Time) *RequestItemFilter {
+	sr.updateIFValue(ItemFilterStartTimeFrom, ToEbayDateTime(t))
+	return sr
+}
+
+// WithItemFilterStartTimeToTime adds StartTimeTo ItemFilter (see WithItemFilterStartTimeTo)
+// The time is converted to UTC eBay format.
+func (sr *RequestItemFilter) WithItemFilterStartTimeToTime(t time.Time) *RequestItemFilter {
+	sr.updateIFValue(ItemFilterStartTimeTo, ToEbayDateTime(t))
+	return sr
+}
+
+// WithItemFilterEndingWithin limits the results to items ending in the next d (e.g. 2 hours).
+// It sets EndTimeTo ItemFilter to now + d.
+//  Values not above 0 are ignored.
+func (sr *RequestItemFilter) WithItemFilterEndingWithin(d time.Duration) *RequestItemFilter {
+	if d <= 0 {
+		sr.inputs.record(string(ItemFilterEndTimeTo), "WithItemFilterEndingWithin", "%s not above 0 is ignored", d)
+		return sr
+	}
+	return sr.WithItemFilterEndTimeToTime(time.Now().Add(d))
+}
+
+// WithItemFilterStartedWithin limits the results to items started in the last d (e.g. 30 minutes).
+// It sets StartTimeFrom ItemFilter to now - d, a time in the past: only end times must be in the future.
+//  Values not above 0 are ignored.
+func (sr *RequestItemFilter) WithItemFilterStartedWithin(d time.Duration) *RequestItemFilter {
+	if d <= 0 {
+		sr.inputs.record(string(ItemFilterStartTimeFrom), "WithItemFilterStartedWithin", "%s not above 0 is ignored", d)
+		return sr
+	}
+	return sr.WithItemFilterStartTimeFromTime(time.Now().Add(-d))
+}
@@ -0,0 +1,251 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testAdvancedResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<findItemsAdvancedResponse xmlns="http://www.ebay.com/marketplace/search/v1/services">
+  <ack>Success</ack>
+  <version>1.13.0</version>
+  <timestamp>2021-11-27T00:28:30.123Z</timestamp>
+  <searchResult count="1">
+    <item>
+      <itemId>123</itemId>
+      <title>harry potter</title>
+    </item>
+  </searchResult>
+  <paginationOutput>
+    <pageNumber>1</pageNumber>
+    <entriesPerPage>1</entriesPerPage>
+    <totalPages>1</totalPages>
+    <totalEntries>1</totalEntries>
+  </paginationOutput>
+</findItemsAdvancedResponse>`
+
+func TestAdvancedRequest_ExecuteContext(t *testing.T) {
+	ts := newTestServer(t, http.StatusOK, testAdvancedResponse)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	res, err := s.NewAdvancedRequest().ExecuteContext(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Success", res.Ack)
+	if assert.Len(t, res.SearchResult.Items, 1) {
+		assert.Equal(t, "123", res.SearchResult.Items[0].ItemID)
+	}
+}
+
+func TestAdvancedRequest_ExecuteContextCanceled(t *testing.T) {
+	ts := newTestServer(t, http.StatusOK, testAdvancedResponse)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.NewAdvancedRequest().ExecuteContext(ctx)
+	assert.True(t, errors.Is(err, context.Canceled), "got %v", err)
+}
+
+func TestGetVersionRequest_ExecuteContextDeadline(t *testing.T) {
+	ts := newServer(t, nil, nil, func(_ int, r *http.Request, _ []byte) (int, string) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		return http.StatusOK, ""
+	})
+	s := NewService("").WithEndpoint(ts.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := s.NewGetVersionRequest().ExecuteContext(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+const testFailureResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<findItemsAdvancedResponse xmlns="http://www.ebay.com/marketplace/search/v1/services">
+  <ack>Failure</ack>
+  <errorMessage>
+    <error>
+      <errorId>3</errorId>
+      <domain>Marketplace</domain>
+      <severity>Error</severity>
+      <category>Request</category>
+      <message>Invalid keywords.</message>
+      <subdomain>Search</subdomain>
+      <parameter name="keywords">x</parameter>
+    </error>
+    <error>
+      <errorId>11</errorId>
+      <domain>Marketplace</domain>
+      <severity>Warning</severity>
+      <category>Request</category>
+      <message>Invalid page number.</message>
+      <subdomain>Search</subdomain>
+    </error>
+  </errorMessage>
+  <version>1.13.0</version>
+  <timestamp>2021-11-27T00:28:30.123Z</timestamp>
+</findItemsAdvancedResponse>`
+
+const testWarningResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<getVersionResponse xmlns="http://www.ebay.com/marketplace/search/v1/services">
+  <ack>Warning</ack>
+  <errorMessage>
+    <error>
+      <errorId>11</errorId>
+      <domain>Marketplace</domain>
+      <severity>Warning</severity>
+      <category>Request</category>
+      <message>Deprecated header.</message>
+      <subdomain>Search</subdomain>
+    </error>
+  </errorMessage>
+  <version>1.13.0</version>
+</getVersionResponse>`
+
+func TestAdvancedRequest_ExecuteFailure(t *testing.T) {
+	ts := newTestServer(t, http.StatusOK, testFailureResponse)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	res, err := s.NewAdvancedRequest().Execute()
+	assert.Equal(t, string(AckFailure), res.Ack)
+
+	var apiErrs *APIErrors
+	if !assert.True(t, errors.As(err, &apiErrs)) {
+		return
+	}
+	assert.Equal(t, AckFailure, apiErrs.Ack)
+	assert.Len(t, apiErrs.Errors, 2)
+	assert.True(t, apiErrs.Has("11"))
+
+	var apiErr *APIError
+	if !assert.True(t, errors.As(err, &apiErr)) {
+		return
+	}
+	assert.Equal(t, "3", apiErr.ErrorID)
+	assert.Equal(t, "Marketplace", apiErr.Domain)
+	assert.Equal(t, "Search", apiErr.Subdomain)
+	assert.Equal(t, ErrorSeverityError, apiErr.Severity)
+	assert.Equal(t, ErrorCategoryRequest, apiErr.Category)
+	assert.Equal(t, []Parameter{{Name: "keywords", Value: "x"}}, apiErr.Parameters)
+}
+
+func TestGetVersionRequest_ExecuteWarning(t *testing.T) {
+	ts := newTestServer(t, http.StatusOK, testWarningResponse)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	res, err := s.NewGetVersionRequest().Execute()
+	if !assert.NoError(t, err) {
+		return
+	}
+	warnings := res.Warnings()
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "11", warnings[0].ErrorID)
+	}
+}
+
+// doerFunc is a test double of Doer
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestService_WithDoer(t *testing.T) {
+	var got *http.Request
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusOK)
+		_, _ = rec.WriteString(testAdvancedResponse)
+		return rec.Result(), nil
+	})
+	s := NewService("app-name").WithGlobalID(GlobalIDEbayDE).WithDoer(doer)
+
+	r := s.NewAdvancedRequest()
+	r.WithKeywords("harry potter")
+	res, err := r.Execute()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Success", res.Ack)
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, EbayEndpointProduction, got.URL.String())
+	assert.Equal(t, string(OperationFindItemsAdvanced), got.Header.Get("X-EBAY-SOA-OPERATION-NAME"))
+	assert.Equal(t, "app-name", got.Header.Get("X-EBAY-SOA-SECURITY-APPNAME"))
+	assert.Equal(t, string(GlobalIDEbayDE), got.Header.Get("X-EBAY-SOA-GLOBAL-ID"))
+	assert.Equal(t, EbayFindingAPIVersion, got.Header.Get("X-EBAY-SOA-SERVICE-VERSION"))
+}
+
+func TestService_WithHTTPClientDoer(t *testing.T) {
+	var operation string
+	ts := newServer(t, nil, nil, func(_ int, r *http.Request, _ []byte) (int, string) {
+		operation = r.Header.Get("X-EBAY-SOA-OPERATION-NAME")
+		return http.StatusOK, testWarningResponse
+	})
+	s := NewService("").WithEndpoint(ts.URL).WithDoer(ts.Client())
+
+	_, err := s.NewGetVersionRequest().Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, string(OperationGetVersion), operation)
+}
+
+const testCompletedItemsResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<findCompletedItemsResponse xmlns="http://www.ebay.com/marketplace/search/v1/services">
+  <ack>Success</ack>
+  <version>1.13.0</version>
+  <timestamp>2021-11-27T00:28:30.123Z</timestamp>
+  <searchResult count="2">
+    <item>
+      <itemId>1</itemId>
+      <sellingStatus>
+        <currentPrice currencyId="USD">25.5</currentPrice>
+        <sellingState>EndedWithSales</sellingState>
+      </sellingStatus>
+    </item>
+    <item>
+      <itemId>2</itemId>
+      <sellingStatus>
+        <currentPrice currencyId="USD">19.99</currentPrice>
+        <sellingState>EndedWithoutSales</sellingState>
+      </sellingStatus>
+    </item>
+  </searchResult>
+  <paginationOutput>
+    <pageNumber>1</pageNumber>
+    <entriesPerPage>2</entriesPerPage>
+    <totalPages>1</totalPages>
+    <totalEntries>2</totalEntries>
+  </paginationOutput>
+</findCompletedItemsResponse>`
+
+func TestCompletedItemsRequest_Execute(t *testing.T) {
+	var operation string
+	ts := newServer(t, nil, nil, func(_ int, r *http.Request, _ []byte) (int, string) {
+		operation = r.Header.Get("X-EBAY-SOA-OPERATION-NAME")
+		return http.StatusOK, testCompletedItemsResponse
+	})
+	s := NewService("").WithEndpoint(ts.URL)
+
+	res, err := s.NewCompletedItemsRequest().Execute()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, string(OperationFindCompletedItems), operation)
+	assert.Equal(t, 2, res.PaginationOutput.TotalEntries)
+	if assert.Len(t, res.SearchResult.Items, 2) {
+		assert.True(t, res.SearchResult.Items[0].SellingStatus.Sold())
+		assert.False(t, res.SearchResult.Items[1].SellingStatus.Sold())
+	}
+}
@@ -508,6 +508,69 @@ func TestInEbayStoresRequest_GetBody(t *testing.T) {
 	}
 }
 
+func TestCompletedItemsRequest_GetBody(t *testing.T) {
+	service := NewService("")
+
+	type tcase struct {
+		filename string
+		request  *CompletedItemsRequest
+	}
+
+	tests := make([]tcase, 0)
+
+	request1 := service.NewCompletedItemsRequest()
+	request1.WithKeywords("Garmin nuvi 1300 Automotive GPS Receiver")
+	request1.WithCategoryID("156955")
+	request1.WithItemFilterCondition(ConditionUsed)
+	request1.WithItemFilterFreeShippingOnly(true)
+	request1.WithItemFilterSoldItemsOnly(true)
+	request1.WithSortOrder(SortOrderPricePlusShippingLowest)
+	request1.WithSortOrder(SortOrderWatchCountDecreaseSort)
+	request1.WithPageLimit(2)
+	tests = append(tests, tcase{
+		filename: "Basic.xml",
+		request:  request1,
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			got, err := tt.request.GetBody()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var req1 CompletedItemsRequest
+			err = xml.Unmarshal(got, &req1)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			f, err := os.Open(path.Join("testdata", "request", "xml", "completeditems", tt.filename))
+			if !assert.NoError(t, err) {
+				return
+			}
+			buf := bytes.Buffer{}
+			_, err = buf.ReadFrom(f)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var req2 CompletedItemsRequest
+			err = xml.Unmarshal(buf.Bytes(), &req2)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.EqualValues(t, req1.AspectFilter, req2.AspectFilter)
+			assert.EqualValues(t, req1.ItemFilter, req2.ItemFilter)
+			assert.EqualValues(t, req1.OutputSelector, req2.OutputSelector)
+			assert.EqualValues(t, req1.CategoryID, req2.CategoryID)
+			assert.Equal(t, req1.RequestStandard, req2.RequestStandard)
+			assert.Equal(t, req1.Keywords, req2.Keywords)
+		})
+	}
+}
+
 func TestGetHistogramsRequest_GetBody(t *testing.T) {
 	service := NewService("")
 
@@ -669,3 +732,49 @@ func TestGetVersionRequest_GetBody(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestItemFilter_Deterministic(t *testing.T) {
+	s := NewService("")
+	newBody := func() []byte {
+		r := s.NewAdvancedRequest()
+		r.WithItemFilterMaxPrice(100)
+		r.WithItemFilterCondition(ConditionNew, ConditionUsed, ConditionNew)
+		r.WithItemFilterSeller("b", "a")
+		r.WithItemFilterFreeShippingOnly(true)
+		body, err := r.GetBody()
+		assert.NoError(t, err)
+		return body
+	}
+	body := newBody()
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, body, newBody())
+	}
+
+	r := s.NewAdvancedRequest()
+	r.WithItemFilterSeller("b", "a", "b")
+	r.WithItemFilterCondition(ConditionNew)
+	assert.Equal(t, []ServiceItemFilter{
+		{Name: string(ItemFilterCondition), Value: []string{string(ConditionNew)}},
+		{Name: string(ItemFilterSeller), Value: []string{"b", "a"}},
+	}, r.ItemFilters())
+}
+
+func TestRequestItemFilter_ChangedAfterBody(t *testing.T) {
+	r := NewService("").NewAdvancedRequest()
+	r.WithItemFilterMaxPrice(100)
+	_, err := r.GetBody()
+	assert.NoError(t, err)
+
+	r.WithItemFilterMinPrice(10)
+	r.RemoveItemFilter(ItemFilterMaxPrice)
+	body, err := r.GetBody()
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), string(ItemFilterMinPrice))
+	assert.NotContains(t, string(body), string(ItemFilterMaxPrice))
+
+	r.ClearItemFilters()
+	body, err = r.GetBody()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "itemFilter")
+	assert.Empty(t, r.ItemFilters())
+}
@@ -40,7 +40,7 @@ type ByKeywordsResponse struct {
 	// ItemSearchURL is a URL to view the search results on the eBay web site.
 	// The search results on the web site will use the same pagination as the API search results.
 	ItemSearchURL string `xml:"itemSearchURL"`
-	PaginationOutput
+	ResponsePaginationOutput
 	ResponseSearchResult
 }
 
@@ -71,9 +71,25 @@ type InEbayStoresResponse struct {
 	ResponseSearchResult
 }
 
+// CompletedItemsResponse represents findCompletedItemsResponse
+type CompletedItemsResponse struct {
+	XMLName xml.Name `xml:"findCompletedItemsResponse"`
+	ResponseAspectHistogramContainer
+	ResponseCategoryHistogramContainer
+	ResponseConditionHistogramContainer
+	responseStandard
+	// ItemSearchURL is a URL to view the search results on the eBay web site.
+	// The search results on the web site will use the same pagination as the API search results.
+	ItemSearchURL string `xml:"itemSearchURL"`
+	ResponsePaginationOutput
+	ResponseSearchResult
+}
+
 // GetHistogramsResponse represents getHistogramsResponse
 type GetHistogramsResponse struct {
 	XMLName xml.Name `xml:"getHistogramsResponse"`
+	ResponseAspectHistogramContainer
+	ResponseCategoryHistogramContainer
 	responseStandard
 }
 
@@ -9,12 +9,38 @@ type responseStandard struct {
 	// but are instead reported as part of a SOAP fault.
 	ErrorMessage []Error `xml:"errorMessage>error"`
 	// Timestamp  represents the date and time when eBay processed the request.
-	Timestamp string `xml:"timestamp"`
+	Timestamp EbayTime `xml:"timestamp"`
 	// Version is the release version that eBay used to process the request. Developer Technical Support
 	// may ask you for the version value if you work with them to troubleshoot issues.
 	Version string `xml:"version"`
 }
 
+// Warnings returns warnings reported by eBay in errorMessage of the response.
+// The call is acknowledged with Warning if eBay processed it, but there were warnings.
+func (rs responseStandard) Warnings() []*APIError {
+	var warnings []*APIError
+	for _, e := range rs.ErrorMessage {
+		if AckValue(rs.Ack) == AckWarning || ErrorSeverity(e.Severity) == ErrorSeverityWarning {
+			warnings = append(warnings, newAPIError(e))
+		}
+	}
+	return warnings
+}
+
+// apiError returns *APIErrors if eBay acknowledged the call with Failure or PartialFailure
+func (rs responseStandard) apiError() error {
+	switch AckValue(rs.Ack) {
+	case AckFailure, AckPartialFailure:
+	default:
+		return nil
+	}
+	apiErrs := &APIErrors{Ack: AckValue(rs.Ack)}
+	for _, e := range rs.ErrorMessage {
+		apiErrs.Errors = append(apiErrs.Errors, newAPIError(e))
+	}
+	return apiErrs
+}
+
 type Error struct {
 	Category    string      `xml:"category"`
 	Domain      string      `xml:"domain"`
@@ -60,6 +86,30 @@ type ValueHistogram struct {
 	Count int64 `xml:"count"`
 }
 
+// Aspect returns the aspect with given name
+func (ahc AspectHistogramContainer) Aspect(name string) (Aspect, bool) {
+	for _, aspect := range ahc.Aspects {
+		if aspect.Name == name {
+			return aspect, true
+		}
+	}
+	return Aspect{}, false
+}
+
+// ValueCounts returns item counts of the aspect with given name by value names.
+// Returns nil if there is no such aspect.
+func (ahc AspectHistogramContainer) ValueCounts(name string) map[string]int64 {
+	aspect, ok := ahc.Aspect(name)
+	if !ok {
+		return nil
+	}
+	counts := make(map[string]int64, len(aspect.ValueHistograms))
+	for _, vh := range aspect.ValueHistograms {
+		counts[vh.ValueName] = vh.Count
+	}
+	return counts
+}
+
 /*
 =====================================================
 */
@@ -86,6 +136,51 @@ type CategoryHistogram struct {
 	Count                   int64               `xml:"count"`
 }
 
+// Walk visits category histograms and their child category histograms depth-first.
+// Depth of top level categories is 0. Walk stops if fn returns false.
+func (chc CategoryHistogramContainer) Walk(fn func(ch CategoryHistogram, depth int) bool) {
+	walkCategoryHistograms(chc.CategoryHistograms, 0, fn)
+}
+
+// Find returns the category histogram with given category ID from any level of the tree
+func (chc CategoryHistogramContainer) Find(categoryID string) (CategoryHistogram, bool) {
+	var found CategoryHistogram
+	ok := false
+	chc.Walk(func(ch CategoryHistogram, _ int) bool {
+		if ch.CategoryId == categoryID {
+			found, ok = ch, true
+			return false
+		}
+		return true
+	})
+	return found, ok
+}
+
+// Leaves returns category histograms without child category histograms
+func (chc CategoryHistogramContainer) Leaves() []CategoryHistogram {
+	var leaves []CategoryHistogram
+	chc.Walk(func(ch CategoryHistogram, _ int) bool {
+		if len(ch.ChildCategoryHistograms) == 0 {
+			leaves = append(leaves, ch)
+		}
+		return true
+	})
+	return leaves
+}
+
+// walks category histograms depth-first. Returns false if the walk is stopped.
+func walkCategoryHistograms(chs []CategoryHistogram, depth int, fn func(ch CategoryHistogram, depth int) bool) bool {
+	for _, ch := range chs {
+		if !fn(ch, depth) {
+			return false
+		}
+		if !walkCategoryHistograms(ch.ChildCategoryHistograms, depth+1, fn) {
+			return false
+		}
+	}
+	return true
+}
+
 /*
 =====================================================
 */
@@ -203,6 +298,8 @@ type DiscountPriceInfo struct {
 	PricingTreatment               string  `xml:"pricingTreatment"`
 	SoldOffEbay                    bool    `xml:"soldOffEbay"`
 	SoldOnEbay                     bool    `xml:"soldOnEbay"`
+	// OriginalRetailPriceAmount is OriginalRetailPrice with its currency in minor units
+	OriginalRetailPriceAmount Money `xml:"-"`
 }
 
 type GalleryURL struct {
@@ -211,20 +308,22 @@ type GalleryURL struct {
 }
 
 type ListingInfo struct {
-	BestOfferEnabled       bool   `xml:"bestOfferEnabled"`
-	BuyItNowAvailable      bool   `xml:"buyItNowAvailable"`
-	Gift                   bool   `xml:"gift"`
-	BuyItNowPrice          Price  `xml:"buyItNowPrice"`
-	ConvertedBuyItNowPrice Price  `xml:"convertedBuyItNowPrice"`
-	EndTime                string `xml:"endTime"`
-	ListingType            string `xml:"listingType"`
-	StartTime              string `xml:"startTime"`
-	WatchCount             int    `xml:"watchCount"`
+	BestOfferEnabled       bool     `xml:"bestOfferEnabled"`
+	BuyItNowAvailable      bool     `xml:"buyItNowAvailable"`
+	Gift                   bool     `xml:"gift"`
+	BuyItNowPrice          Price    `xml:"buyItNowPrice"`
+	ConvertedBuyItNowPrice Price    `xml:"convertedBuyItNowPrice"`
+	EndTime                EbayTime `xml:"endTime"`
+	ListingType            string   `xml:"listingType"`
+	StartTime              EbayTime `xml:"startTime"`
+	WatchCount             int      `xml:"watchCount"`
 }
 
 type Price struct {
 	Value      float64 `xml:",cdata"`
 	CurrencyID string  `xml:"currencyId,attr"`
+	// Amount is Value with CurrencyID in minor units. Use it for sums and comparisons.
+	Amount Money `xml:"-"`
 }
 
 type Category struct {
@@ -241,11 +340,16 @@ type SellerInfo struct {
 }
 
 type SellingStatus struct {
-	BidCount              int    `xml:"bidCount"`
-	ConvertedCurrentPrice Price  `xml:"convertedCurrentPrice"`
-	CurrentPrice          Price  `xml:"currentPrice"`
-	SellingState          string `xml:"sellingState"`
-	TimeLeft              string `xml:"timeLeft"`
+	BidCount              int          `xml:"bidCount"`
+	ConvertedCurrentPrice Price        `xml:"convertedCurrentPrice"`
+	CurrentPrice          Price        `xml:"currentPrice"`
+	SellingState          string       `xml:"sellingState"`
+	TimeLeft              EbayDuration `xml:"timeLeft"`
+}
+
+// Sold checks if the completed listing has ended with sales
+func (ss SellingStatus) Sold() bool {
+	return SellingStateOption(ss.SellingState) == SellingStateEndedWithSales
 }
 
 type ShippingInfo struct {
@@ -0,0 +1,111 @@
+package finding
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestGetHistogramsResponse_Unmarshal(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "response", "xml", "gethistograms", "Basic.xml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var res GetHistogramsResponse
+	if !assert.NoError(t, xml.Unmarshal(data, &res)) {
+		return
+	}
+	assert.Equal(t, "Success", res.Ack)
+
+	chc := res.CategoryHistogramContainer
+	if assert.Len(t, chc.CategoryHistograms, 1) {
+		assert.Equal(t, "Music", chc.CategoryHistograms[0].CategoryName)
+		assert.EqualValues(t, 5317390, chc.CategoryHistograms[0].Count)
+	}
+
+	var visited []string
+	var depths []int
+	chc.Walk(func(ch CategoryHistogram, depth int) bool {
+		visited = append(visited, ch.CategoryId)
+		depths = append(depths, depth)
+		return true
+	})
+	assert.Equal(t, []string{"11233", "176985", "176984"}, visited)
+	assert.Equal(t, []int{0, 1, 1}, depths)
+
+	cds, ok := chc.Find("176984")
+	assert.True(t, ok)
+	assert.Equal(t, "CDs", cds.CategoryName)
+	_, ok = chc.Find("1")
+	assert.False(t, ok)
+	assert.Len(t, chc.Leaves(), 2)
+
+	ahc := res.AspectHistogramContainer
+	assert.Equal(t, "Music", ahc.DomainName)
+	assert.Equal(t, map[string]int64{"Vinyl": 2961046, "CD": 2044566}, ahc.ValueCounts("Format"))
+	assert.Nil(t, ahc.ValueCounts("Color"))
+	genre, ok := ahc.Aspect("Genre")
+	assert.True(t, ok)
+	assert.Len(t, genre.ValueHistograms, 1)
+}
+
+func TestItem_UnmarshalTimes(t *testing.T) {
+	var res AdvancedResponse
+	err := xml.Unmarshal([]byte(`<findItemsAdvancedResponse xmlns="http://www.ebay.com/marketplace/search/v1/services">
+  <ack>Success</ack>
+  <timestamp>2021-11-27T00:28:30.123Z</timestamp>
+  <searchResult count="1">
+    <item>
+      <itemId>1</itemId>
+      <title>Harry &amp; Potter</title>
+      <sellingStatus><timeLeft>P2DT23H32M51S</timeLeft></sellingStatus>
+      <listingInfo>
+        <startTime>2021-11-20T00:00:00.000Z</startTime>
+        <endTime>2021-11-30T00:01:21.000Z</endTime>
+      </listingInfo>
+    </item>
+  </searchResult>
+</findItemsAdvancedResponse>`), &res)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, EbayTime{Time: time.Date(2021, 11, 27, 0, 28, 30, 123e6, time.UTC), Raw: "2021-11-27T00:28:30.123Z"},
+		res.Timestamp)
+	if !assert.Len(t, res.SearchResult.Items, 1) {
+		return
+	}
+	item := res.SearchResult.Items[0]
+	assert.Equal(t, "Harry & Potter", item.Title)
+	assert.Equal(t, time.Date(2021, 11, 20, 0, 0, 0, 0, time.UTC), item.ListingInfo.StartTime.Time)
+	assert.Equal(t, "2021-11-30T00:01:21.000Z", item.ListingInfo.EndTime.Raw)
+	assert.Equal(t, EbayDuration{Duration: 71*time.Hour + 32*time.Minute + 51*time.Second, Raw: "P2DT23H32M51S"},
+		item.SellingStatus.TimeLeft)
+}
+
+func TestItem_UnmarshalMalformedTime(t *testing.T) {
+	var res AdvancedResponse
+	err := xml.Unmarshal([]byte(`<findItemsAdvancedResponse><searchResult count="1"><item>
+<listingInfo><endTime>yesterday</endTime></listingInfo><itemId>123</itemId>
+</item></searchResult></findItemsAdvancedResponse>`), &res)
+	assert.EqualError(t, err, `item 123: parsing endTime "yesterday": invalid date-time`)
+}
+
+func TestEbayDuration_UnmarshalCalendar(t *testing.T) {
+	var v struct {
+		TimeLeft EbayDuration `xml:"timeLeft"`
+	}
+	err := xml.Unmarshal([]byte(`<sellingStatus><timeLeft>P1MT2H</timeLeft></sellingStatus>`), &v)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, EbayDuration{Raw: "P1MT2H"}, v.TimeLeft)
+	d, err := v.TimeLeft.At(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, 28*24*time.Hour+2*time.Hour, d)
+
+	err = xml.Unmarshal([]byte(`<sellingStatus><timeLeft>2 days</timeLeft></sellingStatus>`), &v)
+	assert.Error(t, err)
+}
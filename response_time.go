@@ -0,0 +1,108 @@
+package finding
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EbayTime is eBay date-time field of responses (e.g. 2021-11-27T00:28:30.123Z)
+type EbayTime struct {
+	// Time is the parsed time in UTC
+	Time time.Time
+	// Raw is the value as returned by eBay
+	Raw string
+}
+
+// String implements fmt.Stringer interface
+func (t EbayTime) String() string {
+	return t.Raw
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface. Empty value is zero time.
+func (t *EbayTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw string
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*t = EbayTime{Raw: raw}
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return nil
+	}
+	parsed, err := FromEbayDateTime(s)
+	if err != nil {
+		if parsed, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			return fmt.Errorf("parsing %s %q: invalid date-time", start.Name.Local, raw)
+		}
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// EbayDuration is eBay duration field of responses (e.g. P2DT23H32M51S)
+type EbayDuration struct {
+	// Duration is the parsed duration. It's 0 for durations with years or months, use At to count them.
+	Duration time.Duration
+	// Raw is the value as returned by eBay
+	Raw string
+}
+
+// String implements fmt.Stringer interface
+func (d EbayDuration) String() string {
+	return d.Raw
+}
+
+// At returns the duration with years and months counted from ref
+// (e.g. Timestamp of the response for TimeLeft, see ParseEbayDurationAt). Empty value is zero duration.
+func (d EbayDuration) At(ref time.Time) (time.Duration, error) {
+	s := strings.TrimSpace(d.Raw)
+	if s == "" {
+		return 0, nil
+	}
+	return ParseEbayDurationAt(s, ref)
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface. Empty value is zero duration.
+// Years and months have no fixed length: Duration of such values is left 0 and only Raw is set (see At).
+func (d *EbayDuration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var raw string
+	if err := dec.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*d = EbayDuration{Raw: raw}
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return nil
+	}
+	p, err := parseISODuration(s)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", start.Name.Local, err)
+	}
+	if p.years == 0 && p.months == 0 {
+		d.Duration = p.fixed
+	}
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+// Errors of malformed fields name the item ID, e.g. item 123: parsing endTime "x": invalid date-time.
+func (it *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		ItemID string `xml:"itemId"`
+		Inner  []byte `xml:",innerxml"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	type item Item
+	var v item
+	body := append(append([]byte("<item>"), raw.Inner...), "</item>"...)
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(&v); err != nil {
+		return fmt.Errorf("item %s: %w", raw.ItemID, err)
+	}
+	*it = Item(v)
+	return nil
+}
@@ -0,0 +1,159 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy describes how failed calls are retried.
+// Only retriable errors are retried (see IsRetriable).
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the delay before the second attempt. Every next delay is doubled.
+	BaseBackoff time.Duration
+	// MaxBackoff limits the delay between attempts. Zero means the limit of 1 hour.
+	MaxBackoff time.Duration
+	// Jitter is a fraction (0 - 1) of the delay which is randomly added or subtracted from it.
+	Jitter float64
+	// OnAttempt is called after every attempt (if not nil)
+	OnAttempt func(RetryAttempt)
+}
+
+// RetryAttempt describes the result of a single attempt of the call
+type RetryAttempt struct {
+	// Attempt is the number of the attempt starting from 1
+	Attempt int
+	// Err is the error of the attempt (nil if succeeded)
+	Err error
+	// Backoff is the delay before the next attempt. Zero if the call won't be retried.
+	Backoff time.Duration
+}
+
+// retryBackoffLimit limits the delay between attempts if RetryPolicy.MaxBackoff isn't set
+const retryBackoffLimit = time.Hour
+
+// backoff returns delay after given attempt (starting from 1)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	limit := p.MaxBackoff
+	if limit <= 0 {
+		limit = retryBackoffLimit
+	}
+	d := p.BaseBackoff
+	for i := 1; i < attempt && d < limit; i++ {
+		// doubling is stopped at the limit, so it can't overflow
+		if d > limit/2 {
+			d = limit
+			break
+		}
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	if p.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	if d > limit {
+		d = limit
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d
+}
+
+// HTTPError is returned when the Finding API responds with non-200 status code
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+	// APIErrors contains errors reported by eBay in the response body (if any)
+	APIErrors *APIErrors
+}
+
+// Error implements error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("status code %d: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns errors reported by eBay in the response body
+func (e *HTTPError) Unwrap() error {
+	if e.APIErrors == nil {
+		return nil
+	}
+	return e.APIErrors
+}
+
+// transportError is returned when the call can't be sent or its response can't be received
+type transportError struct {
+	err error
+}
+
+// Error implements error interface
+func (e *transportError) Error() string {
+	return "sending req: " + e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// IsRetriable checks if the call failed with error which can disappear on retry:
+// transport errors, 500, 502, 503 and 504 status codes and eBay errors in System category.
+// Errors in Request category are never retriable.
+func IsRetriable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var tErr *transportError
+	if errors.As(err, &tErr) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErrs *APIErrors
+	if errors.As(err, &apiErrs) {
+		system := false
+		for _, apiErr := range apiErrs.Errors {
+			switch apiErr.Category {
+			case ErrorCategoryRequest:
+				return false
+			case ErrorCategorySystem:
+				system = true
+			}
+		}
+		if system {
+			return true
+		}
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
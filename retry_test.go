@@ -0,0 +1,124 @@
+package finding
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testSystemErrorBody = `<?xml version="1.0" encoding="UTF-8"?>
+<errorMessage xmlns="http://www.ebay.com/marketplace/search/v1/services">
+  <error>
+    <errorId>10001</errorId>
+    <domain>Marketplace</domain>
+    <severity>Error</severity>
+    <category>System</category>
+    <message>Service call has exceeded the number of times the operation is allowed to be called</message>
+    <subdomain>Search</subdomain>
+  </error>
+</errorMessage>`
+
+func TestRequest_ExecuteRetry(t *testing.T) {
+	var calls int32
+	ts := newSequenceServer(t, &calls,
+		[]int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+		[]string{"unavailable", testSystemErrorBody, testAdvancedResponse})
+
+	var attempts []RetryAttempt
+	s := NewService("").WithEndpoint(ts.URL).WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		Jitter:      0.5,
+		OnAttempt: func(a RetryAttempt) {
+			attempts = append(attempts, a)
+		},
+	})
+
+	res, err := s.NewAdvancedRequest().Execute()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Success", res.Ack)
+	assert.EqualValues(t, 3, calls)
+	if assert.Len(t, attempts, 3) {
+		var httpErr *HTTPError
+		assert.True(t, errors.As(attempts[0].Err, &httpErr))
+		assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+		var apiErr *APIError
+		assert.True(t, errors.As(attempts[1].Err, &apiErr))
+		assert.Equal(t, ErrorCategorySystem, apiErr.Category)
+		assert.NoError(t, attempts[2].Err)
+		assert.Zero(t, attempts[2].Backoff)
+	}
+}
+
+func TestRequest_ExecuteNoRetryOnRequestError(t *testing.T) {
+	var calls int32
+	ts := newSequenceServer(t, &calls, []int{http.StatusOK}, []string{testFailureResponse})
+	s := NewService("").WithEndpoint(ts.URL).WithRetryPolicy(RetryPolicy{MaxAttempts: 3})
+
+	_, err := s.NewAdvancedRequest().Execute()
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, calls)
+}
+
+func TestRequest_ExecuteRetryExhausted(t *testing.T) {
+	var calls int32
+	ts := newSequenceServer(t, &calls, []int{http.StatusBadGateway}, []string{"bad gateway"})
+	s := NewService("").WithEndpoint(ts.URL).WithRetryPolicy(RetryPolicy{MaxAttempts: 2})
+
+	_, err := s.NewGetVersionRequest().Execute()
+	var httpErr *HTTPError
+	if assert.True(t, errors.As(err, &httpErr)) {
+		assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	}
+	assert.EqualValues(t, 2, calls)
+}
+
+func TestIsRetriable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"transport", &transportError{err: errors.New("connection reset")}, true},
+		{"status 503", &HTTPError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"status 400", &HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{"status 500 request error", newHTTPError(http.StatusInternalServerError,
+			[]byte(`<errorMessage><error><category>Request</category></error></errorMessage>`)), false},
+		{"system error", &APIErrors{Ack: AckFailure, Errors: []*APIError{{Category: ErrorCategorySystem}}}, true},
+		{"application error", &APIErrors{Ack: AckFailure, Errors: []*APIError{{Category: ErrorCategoryApplication}}}, false},
+		{"parsing", errors.New("parsing response body: EOF"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetriable(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, p.backoff(4))
+	assert.Equal(t, time.Second, p.backoff(10))
+	assert.Equal(t, time.Second, p.backoff(100))
+}
+
+func TestRetryPolicy_backoffWithoutMax(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 100 * time.Millisecond}
+	assert.Equal(t, 800*time.Millisecond, p.backoff(4))
+	assert.Equal(t, time.Hour, p.backoff(64))
+	assert.Equal(t, time.Hour, p.backoff(1000))
+
+	p.Jitter = 0.5
+	for _, attempt := range []int{64, 1000} {
+		d := p.backoff(attempt)
+		assert.True(t, d >= 30*time.Minute && d <= time.Hour, d)
+	}
+}
@@ -1,7 +1,10 @@
 package finding
 
 import (
-	"github.com/go-resty/resty/v2"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
 	"time"
 )
 
@@ -13,6 +16,14 @@ type Service struct {
 	securityAppName string
 	timeout         time.Duration
 	pageLimit       int
+	retryPolicy     RetryPolicy
+	rateLimiter     RateLimiter
+	quota           *Quota
+	skipValidation  bool
+	strict          bool
+
+	mu   sync.Mutex
+	doer Doer
 }
 
 // NewService creates new Ebay Finding API service
@@ -66,33 +77,112 @@ func (s *Service) WithPageLimit(limit int) *Service {
 	return s
 }
 
-// creates new http client (resty)
-func (s *Service) newHTTPClient() *resty.Client {
-	return resty.New().
-		SetHeader("X-EBAY-SOA-SERVICE-VERSION", s.version).
-		//SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsAdvanced)).
-		SetHeader("X-EBAY-SOA-SECURITY-APPNAME", s.securityAppName).
-		SetHeader("X-EBAY-SOA-REQUEST-DATA-FORMAT", EbayRequestDataFormat).
-		SetHeader("X-EBAY-SOA-RESPONSE-DATA-FORMAT", EbayResponseDataFormat).
-		SetHeader("X-EBAY-SOA-GLOBAL-ID", s.globalID).
-		SetTimeout(s.timeout)
+// WithValidation enables or disables validation of requests before sending (see AdvancedRequest.Validate).
+// Requests are validated by default.
+func (s *Service) WithValidation(enabled bool) *Service {
+	s.skipValidation = !enabled
+	return s
+}
+
+// WithStrictMode enables or disables strict mode for requests created by the service after this call.
+// In strict mode builders record inputs they change or drop (clamped page limits, skipped categories,
+// truncated keywords, ignored values, etc.). Recorded inputs are returned by Err of the request
+// and the request isn't sent while any are recorded.
+func (s *Service) WithStrictMode(enabled bool) *Service {
+	s.strict = enabled
+	return s
+}
+
+// WithRetryPolicy sets policy of retrying failed calls for all requests of the service.
+// By default calls aren't retried.
+func (s *Service) WithRetryPolicy(policy RetryPolicy) *Service {
+	s.retryPolicy = policy
+	return s
+}
+
+// WithRateLimit limits calls of all requests of the service to rate calls per second with bursts of up to burst calls
+func (s *Service) WithRateLimit(rate float64, burst int) *Service {
+	s.rateLimiter = NewTokenBucket(rate, burst)
+	return s
+}
+
+// WithRateLimiter sets custom RateLimiter for all requests of the service
+func (s *Service) WithRateLimiter(limiter RateLimiter) *Service {
+	s.rateLimiter = limiter
+	return s
+}
+
+// WithDailyQuota limits the number of calls per UTC day for all requests of the service.
+// Calls are counted in store (MemoryQuotaStore if nil). Use FileQuotaStore to share the quota between processes.
+// When the quota is used, calls fail with ErrQuotaExhausted without sending.
+func (s *Service) WithDailyQuota(limit int, store QuotaStore) *Service {
+	s.quota = NewQuota(limit, store)
+	return s
+}
+
+// Quota returns daily call quota of the service (nil if not set)
+func (s *Service) Quota() *Quota {
+	return s.quota
+}
+
+// waits for the rate limiter and reserves the call in the daily quota
+func (s *Service) acquire(ctx context.Context) error {
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.Wait(ctx); err != nil {
+			return fmt.Errorf("waiting for rate limiter: %w", err)
+		}
+	}
+	if s.quota != nil {
+		if err := s.quota.ReserveContext(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithDoer sets Doer which sends HTTP requests of all requests created by the service after this call.
+// *http.Client can be used as Doer. By default, single RestyDoer is shared by all requests of the service.
+// The timeout of the service is applied to every call regardless of Doer.
+func (s *Service) WithDoer(doer Doer) *Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.doer = doer
+	return s
+}
+
+// returns Doer shared by all requests of the service, so connections are reused between requests
+func (s *Service) sharedDoer() Doer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.doer == nil {
+		s.doer = NewRestyDoer()
+	}
+	return s.doer
+}
+
+// returns headers of the call of given operation for the site globalID.
+// Headers are set per call, so the same Doer serves all operations.
+func (s *Service) headers(operation EbayOperation, globalID GlobalID) http.Header {
+	h := make(http.Header, 7)
+	h.Set("Content-Type", "text/xml; charset=utf-8")
+	h.Set("X-EBAY-SOA-SERVICE-VERSION", s.version)
+	h.Set("X-EBAY-SOA-OPERATION-NAME", string(operation))
+	h.Set("X-EBAY-SOA-SECURITY-APPNAME", s.securityAppName)
+	h.Set("X-EBAY-SOA-REQUEST-DATA-FORMAT", EbayRequestDataFormat)
+	h.Set("X-EBAY-SOA-RESPONSE-DATA-FORMAT", EbayResponseDataFormat)
+	h.Set("X-EBAY-SOA-GLOBAL-ID", string(globalID))
+	return h
 }
 
 // NewAdvancedRequest creates new AdvancedRequest
 func (s *Service) NewAdvancedRequest() *AdvancedRequest {
 	req := AdvancedRequest{}
 	req.Initialize()
-	//req.Client = resty.New().
-	//	SetHeader("X-EBAY-SOA-SERVICE-VERSION", s.version).
-	//	SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsAdvanced)).
-	//	SetHeader("X-EBAY-SOA-SECURITY-APPNAME", s.securityAppName).
-	//	SetHeader("X-EBAY-SOA-REQUEST-DATA-FORMAT", EbayRequestDataFormat).
-	//	SetHeader("X-EBAY-SOA-RESPONSE-DATA-FORMAT", EbayResponseDataFormat).
-	//	SetHeader("X-EBAY-SOA-GLOBAL-ID", s.globalID).
-	//	SetTimeout(s.timeout)
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsAdvanced))
+	req.Client = s.sharedDoer()
+	req.operation = OperationFindItemsAdvanced
 	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
 	req.WithPageLimit(s.pageLimit)
 	return &req
 }
@@ -101,9 +191,11 @@ func (s *Service) NewAdvancedRequest() *AdvancedRequest {
 func (s *Service) NewByCategoryRequest() *ByCategoryRequest {
 	req := ByCategoryRequest{}
 	req.Initialize()
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsByCategory))
+	req.Client = s.sharedDoer()
+	req.operation = OperationFindItemsByCategory
 	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
 	req.WithPageLimit(s.pageLimit)
 	return &req
 }
@@ -112,9 +204,11 @@ func (s *Service) NewByCategoryRequest() *ByCategoryRequest {
 func (s *Service) NewByKeywordsRequest() *ByKeywordsRequest {
 	req := ByKeywordsRequest{}
 	req.Initialize()
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsByKeywords))
+	req.Client = s.sharedDoer()
+	req.operation = OperationFindItemsByKeywords
 	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
 	req.WithPageLimit(s.pageLimit)
 	return &req
 }
@@ -123,9 +217,11 @@ func (s *Service) NewByKeywordsRequest() *ByKeywordsRequest {
 func (s *Service) NewByProductRequest() *ByProductRequest {
 	req := ByProductRequest{}
 	req.Initialize()
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsByProduct))
+	req.Client = s.sharedDoer()
+	req.operation = OperationFindItemsByProduct
 	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
 	req.WithPageLimit(s.pageLimit)
 	return &req
 }
@@ -134,9 +230,24 @@ func (s *Service) NewByProductRequest() *ByProductRequest {
 func (s *Service) NewInEbayStoresRequest() *InEbayStoresRequest {
 	req := InEbayStoresRequest{}
 	req.Initialize()
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationFindItemsIneBayStores))
+	req.Client = s.sharedDoer()
+	req.operation = OperationFindItemsIneBayStores
+	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
+	req.WithPageLimit(s.pageLimit)
+	return &req
+}
+
+// NewCompletedItemsRequest creates new CompletedItemsRequest
+func (s *Service) NewCompletedItemsRequest() *CompletedItemsRequest {
+	req := CompletedItemsRequest{}
+	req.Initialize()
+	req.Client = s.sharedDoer()
+	req.operation = OperationFindCompletedItems
 	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
 	req.WithPageLimit(s.pageLimit)
 	return &req
 }
@@ -144,26 +255,30 @@ func (s *Service) NewInEbayStoresRequest() *InEbayStoresRequest {
 // NewGetHistogramsRequest creates new GetHistogramsRequest
 func (s *Service) NewGetHistogramsRequest() *GetHistogramsRequest {
 	req := GetHistogramsRequest{}
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationGetHistograms))
+	req.Client = s.sharedDoer()
+	req.operation = OperationGetHistograms
 	req.URL = s.endpoint
+	req.service = s
 	return &req
 }
 
 // NewGetKeywordsRecommendationRequest creates new GetKeywordsRecommendationRequest
 func (s *Service) NewGetKeywordsRecommendationRequest() *GetKeywordsRecommendationRequest {
 	req := GetKeywordsRecommendationRequest{}
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationGetSearchKeywordsRecommendation))
+	req.Client = s.sharedDoer()
+	req.operation = OperationGetSearchKeywordsRecommendation
 	req.URL = s.endpoint
+	req.service = s
+	req.bindInputs(s.newInputRecorder())
 	return &req
 }
 
 // NewGetVersionRequest creates new GetVersionRequest
 func (s *Service) NewGetVersionRequest() *GetVersionRequest {
 	req := GetVersionRequest{}
-	req.Client = s.newHTTPClient().
-		SetHeader("X-EBAY-SOA-OPERATION-NAME", string(OperationGetVersion))
+	req.Client = s.sharedDoer()
+	req.operation = OperationGetVersion
 	req.URL = s.endpoint
+	req.service = s
 	return &req
 }
@@ -0,0 +1,86 @@
+package finding
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+func TestService_SharedDoer(t *testing.T) {
+	var conns int64
+	ts := newCountingServer(t, &conns)
+	s := NewService("").WithEndpoint(ts.URL)
+
+	r1 := s.NewAdvancedRequest()
+	r2 := s.NewGetVersionRequest()
+	assert.Same(t, r1.Client, r2.Client)
+
+	for i := 0; i < 5; i++ {
+		_, err := s.NewAdvancedRequest().Execute()
+		assert.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt64(&conns))
+}
+
+func TestService_SharedDoerConnections(t *testing.T) {
+	const calls = 5
+	var shared, perRequest int64
+	sharedServer := newCountingServer(t, &shared)
+	perRequestServer := newCountingServer(t, &perRequest)
+
+	s := NewService("").WithEndpoint(sharedServer.URL)
+	for i := 0; i < calls; i++ {
+		_, err := s.NewAdvancedRequest().Execute()
+		assert.NoError(t, err)
+	}
+
+	// the former behaviour: new client for every request
+	s = NewService("").WithEndpoint(perRequestServer.URL)
+	for i := 0; i < calls; i++ {
+		r := s.NewAdvancedRequest()
+		r.Client = NewRestyDoer()
+		_, err := r.Execute()
+		assert.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 1, atomic.LoadInt64(&shared))
+	assert.EqualValues(t, calls, atomic.LoadInt64(&perRequest))
+}
+
+func BenchmarkService_NewAdvancedRequest(b *testing.B) {
+	s := NewService("")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = s.NewAdvancedRequest()
+	}
+}
+
+// BenchmarkAdvancedRequest_Execute compares the shared client of the service with the former behaviour
+// of creating new client for every request
+func BenchmarkAdvancedRequest_Execute(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		client func() Doer
+	}{
+		{name: "SharedClient"},
+		{name: "ClientPerRequest", client: func() Doer { return NewRestyDoer() }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var conns int64
+			ts := newCountingServer(b, &conns)
+			s := NewService("").WithEndpoint(ts.URL)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r := s.NewAdvancedRequest()
+				if bc.client != nil {
+					r.Client = bc.client()
+				}
+				if _, err := r.Execute(); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(&conns))/float64(b.N), "conns/op")
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package finding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"iter"
+)
+
+// SearchSpecVersion is the version of SearchSpec format written by this package
+const SearchSpecVersion = 1
+
+// SearchSpec is a portable definition of find* request which can be saved as JSON or YAML
+// and loaded with Service.LoadSearch.
+type SearchSpec struct {
+	Version           int                `json:"version" yaml:"version"`
+	Operation         EbayOperation      `json:"operation" yaml:"operation"`
+	GlobalID          GlobalID           `json:"globalId,omitempty" yaml:"globalId,omitempty"`
+	Keywords          string             `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	DescriptionSearch bool               `json:"descriptionSearch,omitempty" yaml:"descriptionSearch,omitempty"`
+	CategoryID        []string           `json:"categoryId,omitempty" yaml:"categoryId,omitempty"`
+	StoreName         string             `json:"storeName,omitempty" yaml:"storeName,omitempty"`
+	Product           *SpecProduct       `json:"product,omitempty" yaml:"product,omitempty"`
+	ItemFilter        []SpecItemFilter   `json:"itemFilter,omitempty" yaml:"itemFilter,omitempty"`
+	AspectFilter      []SpecAspectFilter `json:"aspectFilter,omitempty" yaml:"aspectFilter,omitempty"`
+	OutputSelector    []string           `json:"outputSelector,omitempty" yaml:"outputSelector,omitempty"`
+	SortOrder         string             `json:"sortOrder,omitempty" yaml:"sortOrder,omitempty"`
+	EntriesPerPage    int                `json:"entriesPerPage,omitempty" yaml:"entriesPerPage,omitempty"`
+	PageNumber        int                `json:"pageNumber,omitempty" yaml:"pageNumber,omitempty"`
+	BuyerPostalCode   string             `json:"buyerPostalCode,omitempty" yaml:"buyerPostalCode,omitempty"`
+	Affiliate         *SpecAffiliate     `json:"affiliate,omitempty" yaml:"affiliate,omitempty"`
+}
+
+// SpecProduct is a product of findItemsByProduct search
+type SpecProduct struct {
+	Type string `json:"type" yaml:"type"`
+	ID   string `json:"id" yaml:"id"`
+}
+
+// SpecItemFilter is an item filter of the search
+type SpecItemFilter struct {
+	Name       string   `json:"name" yaml:"name"`
+	Value      []string `json:"value" yaml:"value"`
+	ParamName  string   `json:"paramName,omitempty" yaml:"paramName,omitempty"`
+	ParamValue string   `json:"paramValue,omitempty" yaml:"paramValue,omitempty"`
+}
+
+// SpecAspectFilter is an aspect filter of the search
+type SpecAspectFilter struct {
+	AspectName      string   `json:"aspectName" yaml:"aspectName"`
+	AspectValueName []string `json:"aspectValueName" yaml:"aspectValueName"`
+}
+
+// SpecAffiliate is an affiliate program of the search
+type SpecAffiliate struct {
+	NetworkID  string `json:"networkId,omitempty" yaml:"networkId,omitempty"`
+	TrackingID string `json:"trackingId,omitempty" yaml:"trackingId,omitempty"`
+	CustomID   string `json:"customId,omitempty" yaml:"customId,omitempty"`
+}
+
+// JSON encodes SearchSpec as JSON
+func (spec SearchSpec) JSON() ([]byte, error) {
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// YAML encodes SearchSpec as YAML
+func (spec SearchSpec) YAML() ([]byte, error) {
+	return yaml.Marshal(spec)
+}
+
+// ParseSearchSpecJSON decodes SearchSpec from JSON and checks its version
+func ParseSearchSpecJSON(data []byte) (SearchSpec, error) {
+	var spec SearchSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return SearchSpec{}, fmt.Errorf("decoding search spec: %w", err)
+	}
+	return spec, spec.checkVersion()
+}
+
+// ParseSearchSpecYAML decodes SearchSpec from YAML and checks its version
+func ParseSearchSpecYAML(data []byte) (SearchSpec, error) {
+	var spec SearchSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return SearchSpec{}, fmt.Errorf("decoding search spec: %w", err)
+	}
+	return spec, spec.checkVersion()
+}
+
+// checkVersion checks that SearchSpec version is supported
+func (spec SearchSpec) checkVersion() error {
+	if spec.Version < 1 || spec.Version > SearchSpecVersion {
+		return fmt.Errorf("unsupported search spec version %d", spec.Version)
+	}
+	return nil
+}
+
+// SearchRequest is a find* request (AdvancedRequest, ByCategoryRequest, ByKeywordsRequest,
+// ByProductRequest, InEbayStoresRequest or CompletedItemsRequest)
+type SearchRequest interface {
+	Spec() SearchSpec
+	Validate() error
+	Err() error
+	GetBody() ([]byte, error)
+	Pager(ctx context.Context) *Pager
+	Items(ctx context.Context) iter.Seq2[Item, error]
+	FetchAll(ctx context.Context, workers int) ([]Item, error)
+}
+
+// LoadSearch creates ready-to-execute request of the service from SearchSpec.
+// The request uses GlobalID of the spec if it's set. Use type assertion to get the request type
+// (e.g. *AdvancedRequest for findItemsAdvanced).
+// Fields which aren't supported by the operation of the spec and unknown item filters are reported as an error.
+// Values are set with builders of the request, so their limits and strict mode apply (see Err).
+func (s *Service) LoadSearch(spec SearchSpec) (SearchRequest, error) {
+	if err := spec.checkVersion(); err != nil {
+		return nil, err
+	}
+	var (
+		req    SearchRequest
+		target specTarget
+	)
+	switch spec.Operation {
+	case OperationFindItemsAdvanced:
+		r := s.NewAdvancedRequest()
+		req, target = r, r.specTarget()
+	case OperationFindItemsByCategory:
+		r := s.NewByCategoryRequest()
+		req, target = r, r.specTarget()
+	case OperationFindItemsByKeywords:
+		r := s.NewByKeywordsRequest()
+		req, target = r, r.specTarget()
+	case OperationFindItemsByProduct:
+		r := s.NewByProductRequest()
+		req, target = r, r.specTarget()
+	case OperationFindItemsIneBayStores:
+		r := s.NewInEbayStoresRequest()
+		req, target = r, r.specTarget()
+	case OperationFindCompletedItems:
+		r := s.NewCompletedItemsRequest()
+		req, target = r, r.specTarget()
+	default:
+		return nil, fmt.Errorf("unsupported search spec operation %q", spec.Operation)
+	}
+	if err := target.load(spec); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// specTarget refers to components of find* request. Components the operation doesn't have are nil.
+type specTarget struct {
+	operation         EbayOperation
+	keywords          *RequestKeywords
+	descriptionSearch *RequestDescriptionSearch
+	categories        *RequestCategories
+	storeName         *string
+	product           *RequestProduct
+	itemFilter        *RequestItemFilter
+	aspectFilter      *RequestAspectFilter
+	outputSelector    *RequestOutputSelector
+	standard          *RequestStandard
+	// sortOrders are sort orders supported by the operation, nil if all are supported
+	sortOrders map[SortOrderParameter]struct{}
+}
+
+// spec creates SearchSpec from the components
+func (st specTarget) spec() SearchSpec {
+	spec := SearchSpec{
+		Version:         SearchSpecVersion,
+		Operation:       st.operation,
+		GlobalID:        st.standard.globalID(),
+		SortOrder:       st.standard.SortOrder,
+		EntriesPerPage:  st.standard.PaginationInput.EntriesPerPage,
+		PageNumber:      st.standard.PaginationInput.PageNumber,
+		BuyerPostalCode: st.standard.BuyerPostalCode,
+		OutputSelector:  cloneStrings(st.outputSelector.OutputSelector),
+	}
+	if a := st.standard.Affiliate; a != nil {
+		spec.Affiliate = &SpecAffiliate{NetworkID: a.NetworkID, TrackingID: a.TrackingID, CustomID: a.CustomID}
+	}
+	for _, f := range st.itemFilter.ItemFilters() {
+		spec.ItemFilter = append(spec.ItemFilter, SpecItemFilter{
+			Name:       f.Name,
+			Value:      f.Value,
+			ParamName:  f.ParamName,
+			ParamValue: f.ParamValue,
+		})
+	}
+	if st.keywords != nil {
+		spec.Keywords = st.keywords.Keywords
+	}
+	if st.descriptionSearch != nil {
+		spec.DescriptionSearch = st.descriptionSearch.DescriptionSearch
+	}
+	if st.categories != nil {
+		spec.CategoryID = cloneStrings(st.categories.CategoryID)
+	}
+	if st.storeName != nil {
+		spec.StoreName = *st.storeName
+	}
+	if st.product != nil && (st.product.ProductID.Type != "" || st.product.ProductID.Text != "") {
+		spec.Product = &SpecProduct{Type: st.product.ProductID.Type, ID: st.product.ProductID.Text}
+	}
+	if st.aspectFilter != nil {
+		for _, af := range st.aspectFilter.AspectFilter {
+			spec.AspectFilter = append(spec.AspectFilter, SpecAspectFilter{
+				AspectName:      af.AspectName,
+				AspectValueName: cloneStrings(af.AspectValueName),
+			})
+		}
+	}
+	return spec
+}
+
+// load fills the components from SearchSpec
+func (st specTarget) load(spec SearchSpec) error {
+	var errs []error
+	unsupported := func(field string) {
+		errs = append(errs, fmt.Errorf("%s isn't supported by %s", field, st.operation))
+	}
+
+	if spec.GlobalID != "" {
+		st.standard.WithGlobalID(spec.GlobalID)
+	}
+	if spec.SortOrder != "" {
+		order := SortOrderParameter(spec.SortOrder)
+		if _, ok := st.sortOrders[order]; st.sortOrders != nil && !ok {
+			unsupported("sortOrder " + spec.SortOrder)
+		} else {
+			st.standard.WithSortOrder(order)
+		}
+	}
+	if spec.EntriesPerPage > 0 {
+		st.standard.WithPageLimit(spec.EntriesPerPage)
+	}
+	if spec.PageNumber > 0 {
+		st.standard.WithPageNumber(spec.PageNumber)
+	}
+	if spec.BuyerPostalCode != "" {
+		st.standard.WithBuyerPostalCode(spec.BuyerPostalCode)
+	}
+	if a := spec.Affiliate; a != nil {
+		st.standard.WithAffiliate(a.NetworkID, a.TrackingID, a.CustomID)
+	}
+	for _, osp := range spec.OutputSelector {
+		st.outputSelector.WithOutputSelectors(OutputSelectorParameter(osp))
+	}
+	for _, f := range spec.ItemFilter {
+		if err := st.loadItemFilter(f); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch {
+	case st.keywords != nil:
+		st.keywords.WithKeywords(spec.Keywords)
+	case spec.Keywords != "":
+		unsupported("keywords")
+	}
+	switch {
+	case st.descriptionSearch != nil:
+		st.descriptionSearch.DescriptionSearch = spec.DescriptionSearch
+	case spec.DescriptionSearch:
+		unsupported("descriptionSearch")
+	}
+	switch {
+	case st.categories != nil:
+		st.categories.WithCategoriesID(spec.CategoryID...)
+	case len(spec.CategoryID) > 0:
+		unsupported("categoryId")
+	}
+	switch {
+	case st.storeName != nil:
+		*st.storeName = spec.StoreName
+	case spec.StoreName != "":
+		unsupported("storeName")
+	}
+	switch {
+	case st.product != nil && spec.Product != nil:
+		st.product.ProductID = Product{Type: spec.Product.Type, Text: spec.Product.ID}
+	case st.product == nil && spec.Product != nil:
+		unsupported("product")
+	}
+	switch {
+	case st.aspectFilter != nil:
+		for _, af := range spec.AspectFilter {
+			st.aspectFilter.WithAspectFilter(af.AspectName, af.AspectValueName...)
+		}
+	case len(spec.AspectFilter) > 0:
+		unsupported("aspectFilter")
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("loading search spec: %w", err)
+	}
+	return nil
+}
+
+// loadItemFilter sets item filter of SearchSpec. Unknown filters and filters with wrong number of values are errors.
+func (st specTarget) loadItemFilter(f SpecItemFilter) error {
+	ifp := ItemFilterParameter(f.Name)
+	limit, ok := itemFilterValueLimits[ifp]
+	switch {
+	case !ok:
+		return fmt.Errorf("unknown item filter %q", f.Name)
+	case len(f.Value) == 0:
+		return fmt.Errorf("item filter %s has no values", f.Name)
+	case limit == 1 && len(f.Value) > 1:
+		return fmt.Errorf("item filter %s accepts 1 value", f.Name)
+	case f.ParamName != "" && limit > 1:
+		return fmt.Errorf("item filter %s doesn't accept paramName", f.Name)
+	case f.ParamName != "":
+		st.itemFilter.updateIFValueWithParameter(ifp, f.ParamName, f.ParamValue, f.Value[0])
+	case limit == 1:
+		st.itemFilter.updateIFValue(ifp, f.Value[0])
+	default:
+		st.itemFilter.addIFValues(ifp, limit, f.Value...)
+	}
+	return nil
+}
+
+/*
+==============================================================================
+*/
+
+// specTarget returns components of AdvancedRequest
+func (sr *AdvancedRequest) specTarget() specTarget {
+	return specTarget{
+		operation:         OperationFindItemsAdvanced,
+		keywords:          &sr.RequestKeywords,
+		descriptionSearch: &sr.RequestDescriptionSearch,
+		categories:        &sr.RequestCategories,
+		itemFilter:        &sr.RequestItemFilter,
+		aspectFilter:      &sr.RequestAspectFilter,
+		outputSelector:    &sr.RequestOutputSelector,
+		standard:          &sr.RequestStandard,
+	}
+}
+
+// Spec returns portable definition of AdvancedRequest (see SearchSpec)
+func (sr *AdvancedRequest) Spec() SearchSpec {
+	return sr.specTarget().spec()
+}
+
+// specTarget returns components of ByCategoryRequest
+func (sr *ByCategoryRequest) specTarget() specTarget {
+	return specTarget{
+		operation:      OperationFindItemsByCategory,
+		categories:     &sr.RequestCategories,
+		itemFilter:     &sr.RequestItemFilter,
+		aspectFilter:   &sr.RequestAspectFilter,
+		outputSelector: &sr.RequestOutputSelector,
+		standard:       &sr.RequestStandard,
+	}
+}
+
+// Spec returns portable definition of ByCategoryRequest (see SearchSpec)
+func (sr *ByCategoryRequest) Spec() SearchSpec {
+	return sr.specTarget().spec()
+}
+
+// specTarget returns components of ByKeywordsRequest
+func (sr *ByKeywordsRequest) specTarget() specTarget {
+	return specTarget{
+		operation:         OperationFindItemsByKeywords,
+		keywords:          &sr.RequestKeywords,
+		descriptionSearch: &sr.RequestDescriptionSearch,
+		itemFilter:        &sr.RequestItemFilter,
+		aspectFilter:      &sr.RequestAspectFilter,
+		outputSelector:    &sr.RequestOutputSelector,
+		standard:          &sr.RequestStandard,
+	}
+}
+
+// Spec returns portable definition of ByKeywordsRequest (see SearchSpec)
+func (sr *ByKeywordsRequest) Spec() SearchSpec {
+	return sr.specTarget().spec()
+}
+
+// specTarget returns components of ByProductRequest
+func (sr *ByProductRequest) specTarget() specTarget {
+	return specTarget{
+		operation:      OperationFindItemsByProduct,
+		product:        &sr.RequestProduct,
+		itemFilter:     &sr.RequestItemFilter,
+		outputSelector: &sr.RequestOutputSelector,
+		standard:       &sr.RequestStandard,
+	}
+}
+
+// Spec returns portable definition of ByProductRequest (see SearchSpec)
+func (sr *ByProductRequest) Spec() SearchSpec {
+	return sr.specTarget().spec()
+}
+
+// specTarget returns components of InEbayStoresRequest
+func (sr *InEbayStoresRequest) specTarget() specTarget {
+	return specTarget{
+		operation:      OperationFindItemsIneBayStores,
+		keywords:       &sr.RequestKeywords,
+		categories:     &sr.RequestCategories,
+		storeName:      &sr.StoreName,
+		itemFilter:     &sr.RequestItemFilter,
+		aspectFilter:   &sr.RequestAspectFilter,
+		outputSelector: &sr.RequestOutputSelector,
+		standard:       &sr.RequestStandard,
+	}
+}
+
+// Spec returns portable definition of InEbayStoresRequest (see SearchSpec)
+func (sr *InEbayStoresRequest) Spec() SearchSpec {
+	return sr.specTarget().spec()
+}
+
+// specTarget returns components of CompletedItemsRequest
+func (sr *CompletedItemsRequest) specTarget() specTarget {
+	return specTarget{
+		operation:      OperationFindCompletedItems,
+		keywords:       &sr.RequestKeywords,
+		categories:     &sr.RequestCategories,
+		itemFilter:     &sr.RequestItemFilter,
+		aspectFilter:   &sr.RequestAspectFilter,
+		outputSelector: &sr.RequestOutputSelector,
+		standard:       &sr.RequestStandard,
+		sortOrders:     completedItemsSortOrders,
+	}
+}
+
+// Spec returns portable definition of CompletedItemsRequest (see SearchSpec)
+func (sr *CompletedItemsRequest) Spec() SearchSpec {
+	return sr.specTarget().spec()
+}
@@ -0,0 +1,119 @@
+package finding
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAdvancedRequest_Spec(t *testing.T) {
+	s := NewService("").WithGlobalID(GlobalIDEbayDE)
+	r := s.NewAdvancedRequest()
+	r.WithKeywords("harry potter")
+	r.WithDescriptionSearch(true)
+	r.WithCategoriesID("1", "2")
+	r.WithItemFilterMaxPriceWithCurrency(100, CurrencyIDEUR)
+	r.WithItemFilterCondition(ConditionNew, ConditionUsed)
+	r.WithAspectFilter("Color", "Red", "Blue")
+	r.WithOutputSelectors(OutputSelectorSellerInfo)
+	r.WithSortOrder(SortOrderEndTimeSoonest)
+	r.WithPageLimit(20)
+	r.WithAffiliate("1", "2", "3")
+	body, err := r.GetBody()
+	assert.NoError(t, err)
+
+	spec := r.Spec()
+	assert.Equal(t, GlobalIDEbayDE, spec.GlobalID)
+	assert.Equal(t, []SpecItemFilter{
+		{Name: "Condition", Value: []string{string(ConditionNew), string(ConditionUsed)}},
+		{Name: "MaxPrice", Value: []string{"100.00"}, ParamName: "Currency", ParamValue: string(CurrencyIDEUR)},
+	}, spec.ItemFilter)
+
+	data, err := spec.JSON()
+	assert.NoError(t, err)
+	fromJSON, err := ParseSearchSpecJSON(data)
+	assert.NoError(t, err)
+	assert.Equal(t, spec, fromJSON)
+
+	data, err = spec.YAML()
+	assert.NoError(t, err)
+	fromYAML, err := ParseSearchSpecYAML(data)
+	assert.NoError(t, err)
+	assert.Equal(t, spec, fromYAML)
+
+	loaded, err := NewService("").LoadSearch(fromYAML)
+	if !assert.NoError(t, err) {
+		return
+	}
+	ar, ok := loaded.(*AdvancedRequest)
+	if assert.True(t, ok) {
+		got, err := ar.GetBody()
+		assert.NoError(t, err)
+		assert.Equal(t, string(body), string(got))
+		assert.Equal(t, GlobalIDEbayDE, ar.globalID())
+	}
+}
+
+func TestService_LoadSearchGlobalID(t *testing.T) {
+	var globalID string
+	ts := newServer(t, nil, nil, func(_ int, r *http.Request, _ []byte) (int, string) {
+		globalID = r.Header.Get("X-EBAY-SOA-GLOBAL-ID")
+		return http.StatusOK, pagingResponse("findItemsByCategory", 1, 1, 1)
+	})
+	spec := SearchSpec{Version: SearchSpecVersion, Operation: OperationFindItemsByCategory, GlobalID: GlobalIDEbayGB, CategoryID: []string{"1"}}
+	r, err := NewService("").WithEndpoint(ts.URL).LoadSearch(spec)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = r.(*ByCategoryRequest).Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, string(GlobalIDEbayGB), globalID)
+}
+
+func TestService_LoadSearchErrors(t *testing.T) {
+	s := NewService("")
+	_, err := s.LoadSearch(SearchSpec{Operation: OperationFindItemsAdvanced})
+	assert.EqualError(t, err, "unsupported search spec version 0")
+
+	_, err = s.LoadSearch(SearchSpec{Version: SearchSpecVersion, Operation: OperationGetVersion})
+	assert.EqualError(t, err, `unsupported search spec operation "getVersion"`)
+
+	_, err = s.LoadSearch(SearchSpec{Version: SearchSpecVersion, Operation: OperationFindItemsByProduct, Keywords: "abc", CategoryID: []string{"1"}})
+	assert.EqualError(t, err, "loading search spec: keywords isn't supported by findItemsByProduct\ncategoryId isn't supported by findItemsByProduct")
+
+	_, err = ParseSearchSpecJSON([]byte(`{"version": 2, "operation": "findItemsAdvanced"}`))
+	assert.EqualError(t, err, "unsupported search spec version 2")
+}
+
+func TestParseSearchSpecYAML_Malformed(t *testing.T) {
+	assert.NotPanics(t, func() {
+		_, err := ParseSearchSpecYAML([]byte("0: [:!00 \xef"))
+		assert.Error(t, err)
+	})
+}
+
+func TestService_LoadSearchBuilders(t *testing.T) {
+	s := NewService("").WithStrictMode(true)
+	_, err := s.LoadSearch(SearchSpec{Version: SearchSpecVersion, Operation: OperationFindCompletedItems,
+		SortOrder: string(SortOrderWatchCountDecreaseSort),
+		ItemFilter: []SpecItemFilter{
+			{Name: "Unknown", Value: []string{"1"}},
+			{Name: string(ItemFilterMinPrice), Value: []string{"1", "2"}},
+			{Name: string(ItemFilterSeller)},
+		}})
+	assert.EqualError(t, err, "loading search spec: sortOrder WatchCountDecreaseSort isn't supported by findCompletedItems\n"+
+		"unknown item filter \"Unknown\"\nitem filter MinPrice accepts 1 value\nitem filter Seller has no values")
+
+	r, err := s.LoadSearch(SearchSpec{Version: SearchSpecVersion, Operation: OperationFindItemsAdvanced,
+		CategoryID: []string{"1", "2", "3", "4"},
+		ItemFilter: []SpecItemFilter{{Name: string(ItemFilterListingType), Value: []string{"A", "B", "C", "D", "E", "F", "G"}}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var inputErrs *InputErrors
+	if assert.True(t, errors.As(r.Err(), &inputErrs)) {
+		assert.Len(t, inputErrs.Errors, 2)
+	}
+	assert.Len(t, r.Spec().ItemFilter[0].Value, 6)
+}
@@ -0,0 +1,179 @@
+package finding
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// InputError describes input changed or dropped by a builder of the request in strict mode
+type InputError struct {
+	// Builder is the name of the builder method
+	Builder string
+	// Message describes how the input is changed
+	Message string
+
+	// parameter is the name of the changed request parameter
+	parameter string
+}
+
+// Error implements error interface
+func (e *InputError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Builder, e.Message)
+}
+
+// InputErrors is returned by Err of the request with all inputs changed or dropped in strict mode
+type InputErrors struct {
+	Errors []*InputError
+}
+
+// Error implements error interface
+func (e *InputErrors) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "altered input: " + strings.Join(msgs, "; ")
+}
+
+// inputRecorder records inputs changed or dropped by builders. Nil inputRecorder records nothing.
+// The recorder is shared by all components of the request.
+type inputRecorder struct {
+	mu     sync.Mutex
+	errors []*InputError
+}
+
+// newInputRecorder creates recorder for the request of strict Service, nil otherwise
+func (s *Service) newInputRecorder() *inputRecorder {
+	if !s.strict {
+		return nil
+	}
+	return &inputRecorder{}
+}
+
+// record records changed input of the request parameter
+func (ir *inputRecorder) record(parameter, builder, format string, args ...any) {
+	if ir == nil {
+		return
+	}
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	ir.errors = append(ir.errors, &InputError{Builder: builder, Message: fmt.Sprintf(format, args...), parameter: parameter})
+}
+
+// forget drops inputs recorded for the request parameter. Builders call it when the parameter is set again.
+func (ir *inputRecorder) forget(parameter string) {
+	if ir == nil {
+		return
+	}
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	errs := ir.errors[:0:0]
+	for _, err := range ir.errors {
+		if err.parameter != parameter {
+			errs = append(errs, err)
+		}
+	}
+	ir.errors = errs
+}
+
+// call returns recorder for inputs of a single call of the request: empty in strict mode, nil otherwise
+func (ir *inputRecorder) call() *inputRecorder {
+	if ir == nil {
+		return nil
+	}
+	return &inputRecorder{}
+}
+
+// clone returns recorder with the same recorded inputs
+func (ir *inputRecorder) clone() *inputRecorder {
+	if ir == nil {
+		return nil
+	}
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	errs := make([]*InputError, len(ir.errors))
+	copy(errs, ir.errors)
+	return &inputRecorder{errors: errs}
+}
+
+// err returns *InputErrors if any input is recorded
+func (ir *inputRecorder) err() error {
+	if ir == nil {
+		return nil
+	}
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+	if len(ir.errors) == 0 {
+		return nil
+	}
+	errs := make([]*InputError, len(ir.errors))
+	copy(errs, ir.errors)
+	return &InputErrors{Errors: errs}
+}
+
+// Err returns *InputErrors with inputs changed or dropped by builders of the request in strict mode
+// (see Service.WithStrictMode). Requests with recorded inputs aren't sent.
+// Inputs recorded for a parameter are dropped when the parameter is set again (e.g. WithPageLimit(50) after WithPageLimit(150)).
+func (sr *RequestBasic) Err() error {
+	return sr.inputs.err()
+}
+
+/*
+==============================================================================
+*/
+
+// bindInputs shares ir between components of AdvancedRequest
+func (sr *AdvancedRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestCategories.inputs = ir
+	sr.RequestKeywords.inputs = ir
+	sr.RequestAspectFilter.inputs = ir
+	sr.RequestItemFilter.inputs = ir
+}
+
+// bindInputs shares ir between components of ByCategoryRequest
+func (sr *ByCategoryRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestCategories.inputs = ir
+	sr.RequestAspectFilter.inputs = ir
+	sr.RequestItemFilter.inputs = ir
+}
+
+// bindInputs shares ir between components of ByKeywordsRequest
+func (sr *ByKeywordsRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestKeywords.inputs = ir
+	sr.RequestAspectFilter.inputs = ir
+	sr.RequestItemFilter.inputs = ir
+}
+
+// bindInputs shares ir between components of ByProductRequest
+func (sr *ByProductRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestItemFilter.inputs = ir
+}
+
+// bindInputs shares ir between components of InEbayStoresRequest
+func (sr *InEbayStoresRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestCategories.inputs = ir
+	sr.RequestKeywords.inputs = ir
+	sr.RequestAspectFilter.inputs = ir
+	sr.RequestItemFilter.inputs = ir
+}
+
+// bindInputs shares ir between components of CompletedItemsRequest
+func (sr *CompletedItemsRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestCategories.inputs = ir
+	sr.RequestKeywords.inputs = ir
+	sr.RequestAspectFilter.inputs = ir
+	sr.RequestItemFilter.inputs = ir
+}
+
+// bindInputs shares ir between components of GetKeywordsRecommendationRequest
+func (sr *GetKeywordsRecommendationRequest) bindInputs(ir *inputRecorder) {
+	sr.RequestBasic.inputs = ir
+	sr.RequestKeywords.inputs = ir
+}
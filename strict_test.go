@@ -0,0 +1,131 @@
+package finding
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestService_WithStrictMode(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 1, 1)
+	s := NewService("").WithEndpoint(ts.URL).WithStrictMode(true)
+
+	r := s.NewAdvancedRequest()
+	r.WithPageLimit(150)
+	r.WithCategoriesID("1", "2", "3", "4", "5")
+	r.WithKeywords(strings.Repeat("ab ", 117))
+	r.WithItemFilterMaxDistance(3)
+	r.WithItemFilterMinPrice(-1)
+	r.WithItemFilterListingType(ListingTypeAuction, ListingTypeAuction)
+	r.WithAspectFilter("Color")
+
+	var inputErrs *InputErrors
+	if assert.True(t, errors.As(r.Err(), &inputErrs)) {
+		var builders []string
+		for _, err := range inputErrs.Errors {
+			builders = append(builders, err.Builder)
+		}
+		assert.Equal(t, []string{"WithPageLimit", "WithCategoriesID", "WithKeywords",
+			"WithItemFilterMaxDistance", "WithItemFilterMinPrice", "WithAspectFilter"}, builders)
+		assert.Equal(t, "WithCategoriesID: categories [4 5] are skipped: up to 3 categories", inputErrs.Errors[1].Error())
+	}
+
+	_, err := r.Execute()
+	assert.True(t, errors.As(err, &inputErrs))
+	assert.EqualValues(t, 0, atomic.LoadInt32(&calls))
+}
+
+func TestCompletedItemsRequest_StrictSortOrder(t *testing.T) {
+	r := NewService("").WithStrictMode(true).NewCompletedItemsRequest()
+	r.WithSortOrder(SortOrderEndTimeSoonest)
+	assert.NoError(t, r.Err())
+	r.WithSortOrder(SortOrderWatchCountDecreaseSort)
+	assert.Error(t, r.Err())
+}
+
+func TestService_WithoutStrictMode(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 1, 1)
+	r := NewService("").WithEndpoint(ts.URL).NewByKeywordsRequest()
+	r.WithPageLimit(150)
+	r.WithKeywords("a")
+	assert.NoError(t, r.Err())
+	_, err := r.Execute()
+	assert.NoError(t, err)
+}
+
+func TestAdvancedRequest_StrictGetPage(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 1, 1)
+	r := NewService("").WithEndpoint(ts.URL).WithStrictMode(true).NewAdvancedRequest()
+	r.WithKeywords("ab")
+
+	_, err := r.GetPage(150)
+	var inputErrs *InputErrors
+	assert.True(t, errors.As(err, &inputErrs))
+	assert.NoError(t, r.Err())
+	assert.EqualValues(t, 0, atomic.LoadInt32(&calls))
+
+	_, err = r.GetPage(1)
+	assert.NoError(t, err)
+	_, err = r.Execute()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestAdvancedRequest_StrictResetInput(t *testing.T) {
+	r := NewService("").WithStrictMode(true).NewAdvancedRequest()
+	r.WithPageLimit(150)
+	r.WithItemFilterMinPrice(-1)
+	r.WithKeywords(strings.Repeat("ab ", 117))
+	r.WithItemFilterMaxDistance(3)
+	assert.Error(t, r.Err())
+
+	r.WithPageLimit(50)
+	r.WithItemFilterMinPriceMoney(Money{Minor: 100})
+	r.WithKeywords("a")
+	var inputErrs *InputErrors
+	if assert.True(t, errors.As(r.Err(), &inputErrs)) {
+		var builders []string
+		for _, err := range inputErrs.Errors {
+			builders = append(builders, err.Builder)
+		}
+		assert.Equal(t, []string{"WithKeywords", "WithItemFilterMaxDistance", "WithKeywords"}, builders)
+	}
+
+	r.WithKeywords("ab")
+	r.WithItemFilterMaxDistance(10)
+	assert.NoError(t, r.Err())
+}
+
+func TestAdvancedRequest_StrictForgetInput(t *testing.T) {
+	r := NewService("").WithStrictMode(true).NewAdvancedRequest()
+	r.WithItemFilterMaxDistance(3)
+	r.RemoveItemFilter(ItemFilterMaxDistance)
+	assert.NoError(t, r.Err())
+
+	r.WithItemFilterMaxDistance(3)
+	r.WithItemFilterMinPrice(-1)
+	r.ClearItemFilters()
+	assert.NoError(t, r.Err())
+
+	sellers := make([]string, 101)
+	for i := range sellers {
+		sellers[i] = fmt.Sprint("seller", i)
+	}
+	r.WithItemFilterSeller(sellers...)
+	assert.Error(t, r.Err())
+	r.WithItemFilterSeller("seller0")
+	assert.NoError(t, r.Err())
+
+	r.WithCategoriesID("1", "2", "3", "4")
+	r.WithCategoryID("5")
+	assert.Error(t, r.Err())
+	r.ClearCategories()
+	r.WithCategoriesID("1")
+	assert.NoError(t, r.Err())
+}
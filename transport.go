@@ -0,0 +1,46 @@
+package finding
+
+import (
+	"bytes"
+	"github.com/go-resty/resty/v2"
+	"io"
+	"net/http"
+)
+
+// Doer sends HTTP requests to the Finding API.
+// *http.Client implements Doer, so any custom client (proxy, TLS settings, instrumentation, test double) can be used.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RestyDoer is a Doer which sends requests with resty client. It is used by default.
+type RestyDoer struct {
+	Client *resty.Client
+}
+
+// NewRestyDoer creates new RestyDoer with new resty client
+func NewRestyDoer() *RestyDoer {
+	return &RestyDoer{Client: resty.New()}
+}
+
+// Do sends HTTP request with resty client.
+// Response body is read by resty, so returned response body is an in-memory copy.
+func (d *RestyDoer) Do(req *http.Request) (*http.Response, error) {
+	r := d.Client.R().SetContext(req.Context())
+	r.Header = req.Header.Clone()
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		r.SetBody(body)
+	}
+	res, err := r.Execute(req.Method, req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	raw := res.RawResponse
+	raw.Body = io.NopCloser(bytes.NewReader(res.Body()))
+	return raw, nil
+}
@@ -0,0 +1,314 @@
+package finding
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Violation is a constraint of the Finding API broken by the request
+type Violation struct {
+	// Fields are the item filters or request fields breaking the constraint
+	Fields []string
+	// Message describes the constraint
+	Message string
+}
+
+// String implements fmt.Stringer interface
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", strings.Join(v.Fields, ", "), v.Message)
+}
+
+// ValidationError is returned by Validate with all violations of the request
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Error implements error interface
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// newValidationError returns *ValidationError if there are violations, nil otherwise
+func newValidationError(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
+
+// topRatedSellerGlobalIDs are sites supporting TopRatedSellerOnly item filter
+var topRatedSellerGlobalIDs = map[GlobalID]struct{}{
+	GlobalIDEbayUS:    {},
+	GlobalIDEbayMOTOR: {},
+	GlobalIDEbayGB:    {},
+	GlobalIDEbayIE:    {},
+	GlobalIDEbayDE:    {},
+	GlobalIDEbayAT:    {},
+	GlobalIDEbayCH:    {},
+}
+
+// itemFilterCurrencies are currencies of price item filters
+var itemFilterCurrencies = map[ItemFilterCurrencyIDOption]struct{}{
+	CurrencyIDAUD: {},
+	CurrencyIDCAD: {},
+	CurrencyIDCHF: {},
+	CurrencyIDCNY: {},
+	CurrencyIDEUR: {},
+	CurrencyIDGBP: {},
+	CurrencyIDHKD: {},
+	CurrencyIDINR: {},
+	CurrencyIDMYR: {},
+	CurrencyIDPHP: {},
+	CurrencyIDPLN: {},
+	CurrencyIDSEK: {},
+	CurrencyIDSGD: {},
+	CurrencyIDTWD: {},
+	CurrencyIDUSD: {},
+}
+
+// itemFilterRanges are pairs of min and max item filters
+var itemFilterRanges = [][2]ItemFilterParameter{
+	{ItemFilterFeedbackScoreMin, ItemFilterFeedbackScoreMax},
+	{ItemFilterMinBids, ItemFilterMaxBids},
+	{ItemFilterMinPrice, ItemFilterMaxPrice},
+	{ItemFilterMinQuantity, ItemFilterMaxQuantity},
+}
+
+// violations checks cross-filter constraints of item filters for the site globalID and buyerPostalCode
+func (sr *RequestItemFilter) violations(globalID GlobalID, buyerPostalCode string) []Violation {
+	has := func(ifp ItemFilterParameter) bool {
+		_, ok := sr.ItemFilterMap[ifp]
+		return ok
+	}
+	// boolean item filters are active only when they are true
+	enabled := func(ifp ItemFilterParameter) bool {
+		f, ok := sr.ItemFilterMap[ifp]
+		return ok && len(f.Value) > 0 && f.Value[0] == "true"
+	}
+	var vs []Violation
+	add := func(message string, ifps ...ItemFilterParameter) {
+		fields := make([]string, len(ifps))
+		for i, ifp := range ifps {
+			fields[i] = string(ifp)
+		}
+		vs = append(vs, Violation{Fields: fields, Message: message})
+	}
+
+	if enabled(ItemFilterTopRatedSellerOnly) {
+		for _, ifp := range []ItemFilterParameter{ItemFilterSeller, ItemFilterExcludeSeller} {
+			if has(ifp) {
+				add("cannot be used together", ItemFilterTopRatedSellerOnly, ifp)
+			}
+		}
+		if _, ok := topRatedSellerGlobalIDs[globalID]; !ok {
+			add(fmt.Sprintf("isn't supported for %s", globalID), ItemFilterTopRatedSellerOnly)
+		}
+	}
+	if has(ItemFilterSeller) && has(ItemFilterExcludeSeller) {
+		add("cannot be used together", ItemFilterSeller, ItemFilterExcludeSeller)
+	}
+	if has(ItemFilterLocatedIn) && has(ItemFilterAvailableTo) {
+		add("cannot be used together", ItemFilterLocatedIn, ItemFilterAvailableTo)
+	}
+	if enabled(ItemFilterLocalSearchOnly) {
+		if !has(ItemFilterMaxDistance) {
+			add("must be used together", ItemFilterLocalSearchOnly, ItemFilterMaxDistance)
+		}
+		if globalID != GlobalIDEbayMOTOR {
+			add(fmt.Sprintf("isn't supported for %s", globalID), ItemFilterLocalSearchOnly)
+		}
+	}
+	if has(ItemFilterMaxDistance) && buyerPostalCode == "" {
+		add("requires buyerPostalCode", ItemFilterMaxDistance)
+	}
+	for _, ifp := range []ItemFilterParameter{ItemFilterMinPrice, ItemFilterMaxPrice} {
+		if f, ok := sr.ItemFilterMap[ifp]; ok && f.ParamName == "Currency" {
+			if _, ok := itemFilterCurrencies[ItemFilterCurrencyIDOption(f.ParamValue)]; !ok {
+				add(fmt.Sprintf("currency %q isn't supported", f.ParamValue), ifp)
+			}
+		}
+	}
+	for _, r := range itemFilterRanges {
+		minFilter, minOK := sr.ItemFilterMap[r[0]]
+		maxFilter, maxOK := sr.ItemFilterMap[r[1]]
+		if !minOK || !maxOK || len(minFilter.Value) == 0 || len(maxFilter.Value) == 0 {
+			continue
+		}
+		if minFilter.ParamValue != "" && maxFilter.ParamValue != "" && minFilter.ParamValue != maxFilter.ParamValue {
+			add("must have the same currency", r[0], r[1])
+			continue
+		}
+		minValue, minErr := strconv.ParseFloat(minFilter.Value[0], 64)
+		maxValue, maxErr := strconv.ParseFloat(maxFilter.Value[0], 64)
+		if minErr == nil && maxErr == nil && maxValue < minValue {
+			add(fmt.Sprintf("%s must be greater than or equal to %s", r[1], r[0]), r[0], r[1])
+		}
+	}
+	return vs
+}
+
+// modTimeHistory is how far in the past ModTimeFrom can be
+const modTimeHistory = 90 * 24 * time.Hour
+
+// parseFilterTime parses time of item filter. Times with offset other than UTC are reported by utc.
+func parseFilterTime(value string) (t time.Time, utc bool, err error) {
+	if t, err = FromEbayDateTime(value); err == nil {
+		return t, true, nil
+	}
+	if t, err = time.Parse(time.RFC3339Nano, value); err != nil {
+		return t, false, err
+	}
+	_, offset := t.Zone()
+	return t, offset == 0 && strings.HasSuffix(value, "Z"), nil
+}
+
+// timeViolations checks time item filters at now.
+// Items of completed searches have ended, so their end times aren't required to be in the future.
+func (sr *RequestItemFilter) timeViolations(now time.Time, completed bool) []Violation {
+	var vs []Violation
+	times := map[ItemFilterParameter]time.Time{}
+	for _, ifp := range []ItemFilterParameter{ItemFilterEndTimeFrom, ItemFilterEndTimeTo, ItemFilterModTimeFrom,
+		ItemFilterStartTimeFrom, ItemFilterStartTimeTo} {
+		f, ok := sr.ItemFilterMap[ifp]
+		if !ok || len(f.Value) == 0 {
+			continue
+		}
+		t, utc, err := parseFilterTime(f.Value[0])
+		switch {
+		case err != nil:
+			vs = append(vs, Violation{Fields: []string{string(ifp)}, Message: fmt.Sprintf("%q isn't datetime", f.Value[0])})
+		case !utc:
+			vs = append(vs, Violation{Fields: []string{string(ifp)}, Message: fmt.Sprintf("%q must be in UTC", f.Value[0])})
+		default:
+			times[ifp] = t
+		}
+	}
+
+	if !completed {
+		for _, ifp := range []ItemFilterParameter{ItemFilterEndTimeFrom, ItemFilterEndTimeTo} {
+			if t, ok := times[ifp]; ok && !t.After(now) {
+				vs = append(vs, Violation{Fields: []string{string(ifp)}, Message: "must be in the future"})
+			}
+		}
+	}
+	if t, ok := times[ItemFilterModTimeFrom]; ok {
+		if t.After(now) {
+			vs = append(vs, Violation{Fields: []string{string(ItemFilterModTimeFrom)}, Message: "must be in the past"})
+		} else if t.Before(now.Add(-modTimeHistory)) {
+			vs = append(vs, Violation{Fields: []string{string(ItemFilterModTimeFrom)}, Message: "must be within the last 90 days"})
+		}
+	}
+	for _, r := range [][2]ItemFilterParameter{
+		{ItemFilterEndTimeFrom, ItemFilterEndTimeTo},
+		{ItemFilterStartTimeFrom, ItemFilterStartTimeTo},
+	} {
+		from, fromOK := times[r[0]]
+		to, toOK := times[r[1]]
+		if fromOK && toOK && to.Before(from) {
+			vs = append(vs, Violation{
+				Fields:  []string{string(r[0]), string(r[1])},
+				Message: fmt.Sprintf("%s must be after or equal to %s", r[1], r[0]),
+			})
+		}
+	}
+	return vs
+}
+
+// itemFilterViolations checks item filters of the standard request
+func (sr *RequestStandard) itemFilterViolations(filter *RequestItemFilter) []Violation {
+	vs := filter.violations(sr.globalID(), sr.BuyerPostalCode)
+	now := sr.validationTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return append(vs, filter.timeViolations(now, sr.operation == OperationFindCompletedItems)...)
+}
+
+// validationTimeKey is the context key of the time of validation of time item filters
+type validationTimeKey struct{}
+
+// withValidationTime pins the time of validation of time item filters for pages fetched with ctx,
+// so a search which passes validation on the first page isn't rejected on later pages. Time pinned before is kept.
+func withValidationTime(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(validationTimeKey{}).(time.Time); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, validationTimeKey{}, time.Now())
+}
+
+// validationTime returns the time pinned in ctx by withValidationTime, zero if it isn't pinned
+func validationTime(ctx context.Context) time.Time {
+	t, _ := ctx.Value(validationTimeKey{}).(time.Time)
+	return t
+}
+
+// validationEnabled checks if the request is validated before sending
+func (sr *RequestBasic) validationEnabled() bool {
+	return sr.service == nil || !sr.service.skipValidation
+}
+
+/*
+==============================================================================
+*/
+
+// Validate checks constraints of AdvancedRequest item filters against the request and the site of the Service.
+// It returns *ValidationError with all violations.
+func (sr *AdvancedRequest) Validate() error {
+	return newValidationError(append(sr.itemFilterViolations(&sr.RequestItemFilter), sr.RequestKeywords.violations()...))
+}
+
+// Validate checks constraints of ByCategoryRequest (see AdvancedRequest.Validate)
+func (sr *ByCategoryRequest) Validate() error {
+	return newValidationError(sr.itemFilterViolations(&sr.RequestItemFilter))
+}
+
+// Validate checks constraints of ByKeywordsRequest (see AdvancedRequest.Validate)
+func (sr *ByKeywordsRequest) Validate() error {
+	return newValidationError(append(sr.itemFilterViolations(&sr.RequestItemFilter), sr.RequestKeywords.violations()...))
+}
+
+// Validate checks constraints of ByProductRequest (see AdvancedRequest.Validate)
+func (sr *ByProductRequest) Validate() error {
+	return newValidationError(sr.itemFilterViolations(&sr.RequestItemFilter))
+}
+
+// Validate checks constraints of InEbayStoresRequest (see AdvancedRequest.Validate)
+func (sr *InEbayStoresRequest) Validate() error {
+	return newValidationError(append(sr.itemFilterViolations(&sr.RequestItemFilter), sr.RequestKeywords.violations()...))
+}
+
+// Validate checks constraints of CompletedItemsRequest (see AdvancedRequest.Validate)
+func (sr *CompletedItemsRequest) Validate() error {
+	return newValidationError(append(sr.itemFilterViolations(&sr.RequestItemFilter), sr.RequestKeywords.violations()...))
+}
+
+// Validate checks that GetHistogramsRequest has categoryId
+func (sr *GetHistogramsRequest) Validate() error {
+	var vs []Violation
+	if sr.CategoryId == "" {
+		vs = append(vs, Violation{Fields: []string{"categoryId"}, Message: "is required"})
+	}
+	return newValidationError(vs)
+}
+
+// Validate checks that GetKeywordsRecommendationRequest has keywords
+func (sr *GetKeywordsRecommendationRequest) Validate() error {
+	var vs []Violation
+	if sr.Keywords == "" {
+		vs = append(vs, Violation{Fields: []string{"keywords"}, Message: "is required"})
+	}
+	vs = append(vs, sr.RequestKeywords.violations()...)
+	return newValidationError(vs)
+}
+
+// Validate does nothing: GetVersionRequest has no input parameters
+func (sr *GetVersionRequest) Validate() error {
+	return nil
+}
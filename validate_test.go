@@ -0,0 +1,200 @@
+package finding
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdvancedRequest_Validate(t *testing.T) {
+	s := NewService("")
+	tests := []struct {
+		name   string
+		fill   func(r *AdvancedRequest)
+		fields [][]string
+	}{
+		{
+			name: "valid",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterTopRatedSellerOnly(true)
+				r.WithItemFilterMinPrice(10)
+				r.WithItemFilterMaxPrice(20)
+				r.WithItemFilterMaxDistance(10)
+				r.WithBuyerPostalCode("10001")
+			},
+		},
+		{
+			name: "false boolean filters",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterTopRatedSellerOnly(false)
+				r.WithItemFilterSeller("bob")
+				r.WithItemFilterLocalSearchOnly(false)
+			},
+		},
+		{
+			name: "top rated seller with sellers",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterTopRatedSellerOnly(true)
+				r.WithItemFilterSeller("a")
+				r.WithItemFilterExcludeSeller("b")
+			},
+			fields: [][]string{
+				{"TopRatedSellerOnly", "Seller"},
+				{"TopRatedSellerOnly", "ExcludeSeller"},
+				{"Seller", "ExcludeSeller"},
+			},
+		},
+		{
+			name: "located in with available to",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterLocatedIn("US")
+				r.WithItemFilterAvailableTo("US")
+			},
+			fields: [][]string{{"LocatedIn", "AvailableTo"}},
+		},
+		{
+			name: "local search only",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterLocalSearchOnly(true)
+			},
+			fields: [][]string{{"LocalSearchOnly", "MaxDistance"}, {"LocalSearchOnly"}},
+		},
+		{
+			name: "max distance without postal code",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterMaxDistance(10)
+			},
+			fields: [][]string{{"MaxDistance"}},
+		},
+		{
+			name: "ranges",
+			fill: func(r *AdvancedRequest) {
+				r.WithItemFilterMinPrice(20)
+				r.WithItemFilterMaxPrice(10)
+				r.WithItemFilterMinBids(5)
+				r.WithItemFilterMaxBids(1)
+			},
+			fields: [][]string{{"MinBids", "MaxBids"}, {"MinPrice", "MaxPrice"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := s.NewAdvancedRequest()
+			tt.fill(r)
+			err := r.Validate()
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			if assert.True(t, errors.As(err, &validationErr)) {
+				var fields [][]string
+				for _, v := range validationErr.Violations {
+					fields = append(fields, v.Fields)
+				}
+				assert.Equal(t, tt.fields, fields)
+			}
+		})
+	}
+}
+
+func TestByKeywordsRequest_ValidateGlobalID(t *testing.T) {
+	r := NewService("").WithGlobalID(GlobalIDEbayFR).NewByKeywordsRequest()
+	r.WithItemFilterTopRatedSellerOnly(true)
+	assert.EqualError(t, r.Validate(), "invalid request: TopRatedSellerOnly: isn't supported for EBAY-FR")
+
+	r = NewService("").WithGlobalID(GlobalIDEbayDE).NewByKeywordsRequest()
+	r.WithItemFilterTopRatedSellerOnly(true)
+	assert.NoError(t, r.Validate())
+}
+
+func TestAdvancedRequest_ExecuteValidation(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 1, 1)
+
+	s := NewService("").WithEndpoint(ts.URL)
+	r := s.NewAdvancedRequest()
+	r.WithItemFilterLocatedIn("US")
+	r.WithItemFilterAvailableTo("US")
+	_, err := r.Execute()
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.EqualValues(t, 0, atomic.LoadInt32(&calls))
+
+	s.WithValidation(false)
+	_, err = r.Execute()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestGetHistogramsRequest_Validate(t *testing.T) {
+	ts := newTestServer(t, http.StatusOK, "")
+	r := NewService("").WithEndpoint(ts.URL).NewGetHistogramsRequest()
+	_, err := r.Execute()
+	assert.EqualError(t, err, "invalid request: categoryId: is required")
+}
+
+func TestRequestItemFilter_TimeFilters(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	r := NewService("").NewAdvancedRequest()
+	r.WithItemFilterEndTimeToTime(time.Date(2030, 1, 2, 12, 0, 0, 0, loc))
+	assert.Equal(t, []string{"2030-01-02T10:00:00.000Z"}, r.ItemFilterMap[ItemFilterEndTimeTo].Value)
+	assert.NoError(t, r.Validate())
+
+	r.WithItemFilterEndingWithin(2 * time.Hour)
+	endTimeTo, err := FromEbayDateTime(r.ItemFilterMap[ItemFilterEndTimeTo].Value[0])
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), endTimeTo, time.Minute)
+	r.WithItemFilterStartedWithin(time.Hour)
+	assert.NoError(t, r.Validate())
+}
+
+func TestRequestItemFilter_StartedWithinValidate(t *testing.T) {
+	r := NewService("").NewAdvancedRequest()
+	r.WithItemFilterStartedWithin(30 * time.Minute)
+	startTimeFrom, err := FromEbayDateTime(r.ItemFilterMap[ItemFilterStartTimeFrom].Value[0])
+	assert.NoError(t, err)
+	assert.True(t, startTimeFrom.Before(time.Now()))
+	assert.NoError(t, r.Validate())
+}
+
+func TestRequestItemFilter_TimeViolations(t *testing.T) {
+	now := time.Now()
+	r := NewService("").NewAdvancedRequest()
+	r.WithItemFilterEndTimeFromTime(now.Add(-time.Hour))
+	r.WithItemFilterEndTimeTo("2030-01-02T12:00:00+02:00")
+	r.WithItemFilterModTimeFromTime(now.Add(-100 * 24 * time.Hour))
+	r.WithItemFilterStartTimeFromTime(now.Add(-time.Hour))
+	r.WithItemFilterStartTimeToTime(now.Add(-2 * time.Hour))
+	assert.EqualError(t, r.Validate(), `invalid request: EndTimeTo: "2030-01-02T12:00:00+02:00" must be in UTC; `+
+		"EndTimeFrom: must be in the future; ModTimeFrom: must be within the last 90 days; "+
+		"StartTimeFrom, StartTimeTo: StartTimeTo must be after or equal to StartTimeFrom")
+
+	c := NewService("").NewCompletedItemsRequest()
+	c.WithItemFilterEndTimeFromTime(now.Add(-2 * time.Hour))
+	c.WithItemFilterEndTimeToTime(now.Add(-time.Hour))
+	c.WithItemFilterModTimeFrom("yesterday")
+	assert.EqualError(t, c.Validate(), `invalid request: ModTimeFrom: "yesterday" isn't datetime`)
+}
+
+func TestAdvancedRequest_PagerValidationTime(t *testing.T) {
+	var calls int32
+	ts := newPagingServer(t, &calls, 2, 1)
+	r := NewService("").WithEndpoint(ts.URL).WithPageLimit(1).NewAdvancedRequest()
+	r.WithItemFilterEndTimeFromTime(time.Now().Add(100 * time.Millisecond))
+
+	p := r.Pager(context.Background())
+	assert.True(t, p.Next())
+	time.Sleep(200 * time.Millisecond)
+	assert.True(t, p.Next())
+	assert.False(t, p.Next())
+	assert.NoError(t, p.Err())
+
+	_, err := r.Execute()
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
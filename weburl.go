@@ -0,0 +1,381 @@
+package finding
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// siteDomains are eBay website domains of the sites
+var siteDomains = map[GlobalID]string{
+	GlobalIDEbayUS:    "www.ebay.com",
+	GlobalIDEbayENCA:  "www.ebay.ca",
+	GlobalIDEbayGB:    "www.ebay.co.uk",
+	GlobalIDEbayAU:    "www.ebay.com.au",
+	GlobalIDEbayAT:    "www.ebay.at",
+	GlobalIDEbayFRBE:  "www.befr.ebay.be",
+	GlobalIDEbayFR:    "www.ebay.fr",
+	GlobalIDEbayDE:    "www.ebay.de",
+	GlobalIDEbayMOTOR: "www.ebay.com",
+	GlobalIDEbayIT:    "www.ebay.it",
+	GlobalIDEbayNLBE:  "www.benl.ebay.be",
+	GlobalIDEbayNL:    "www.ebay.nl",
+	GlobalIDEbayES:    "www.ebay.es",
+	GlobalIDEbayCH:    "www.ebay.ch",
+	GlobalIDEbayHK:    "www.ebay.com.hk",
+	GlobalIDEbayIN:    "www.ebay.in",
+	GlobalIDEbayIE:    "www.ebay.ie",
+	GlobalIDEbayMY:    "www.ebay.com.my",
+	GlobalIDEbayFRCA:  "www.cafr.ebay.ca",
+	GlobalIDEbayPH:    "www.ebay.ph",
+	GlobalIDEbaySG:    "www.ebay.com.sg",
+}
+
+// siteCountries are home countries (ISO 3166 codes) of the sites
+var siteCountries = map[GlobalID]string{
+	GlobalIDEbayUS:    "US",
+	GlobalIDEbayENCA:  "CA",
+	GlobalIDEbayGB:    "GB",
+	GlobalIDEbayAU:    "AU",
+	GlobalIDEbayAT:    "AT",
+	GlobalIDEbayFRBE:  "BE",
+	GlobalIDEbayFR:    "FR",
+	GlobalIDEbayDE:    "DE",
+	GlobalIDEbayMOTOR: "US",
+	GlobalIDEbayIT:    "IT",
+	GlobalIDEbayNLBE:  "BE",
+	GlobalIDEbayNL:    "NL",
+	GlobalIDEbayES:    "ES",
+	GlobalIDEbayCH:    "CH",
+	GlobalIDEbayHK:    "HK",
+	GlobalIDEbayIN:    "IN",
+	GlobalIDEbayIE:    "IE",
+	GlobalIDEbayMY:    "MY",
+	GlobalIDEbayFRCA:  "CA",
+	GlobalIDEbayPH:    "PH",
+	GlobalIDEbaySG:    "SG",
+}
+
+// webSortOrders are values of _sop website parameter for sort orders
+var webSortOrders = map[SortOrderParameter]string{
+	SortOrderBestMatch:                "12",
+	SortOrderEndTimeSoonest:           "1",
+	SortOrderStartTimeNewest:          "10",
+	SortOrderPricePlusShippingLowest:  "15",
+	SortOrderPricePlusShippingHighest: "16",
+	SortOrderDistanceNearest:          "7",
+}
+
+// webListingParams are listing types of website parameters
+var webListingParams = map[string]ItemFilterListingTypeOption{
+	"LH_Auction": ListingTypeAuction,
+	"LH_BIN":     ListingTypeFixedPrice,
+	"LH_CAds":    ListingTypeClassified,
+}
+
+// WebSearch is the eBay website search equivalent to a request
+type WebSearch struct {
+	// URL is ebay.<site>/sch URL of the search
+	URL *url.URL
+	// Unmapped lists request fields and item filters which have no website equivalent and aren't in URL
+	Unmapped []string
+}
+
+// webSearchInput is the part of a request mapped to website parameters
+type webSearchInput struct {
+	globalID          GlobalID
+	keywords          string
+	descriptionSearch bool
+	categories        []string
+	aspectFilter      []ServiceAspectFilter
+	itemFilter        *RequestItemFilter
+	standard          *RequestStandard
+}
+
+// webSearch maps the request to website search parameters.
+//
+// Mapped fields: keywords (_nkw), descriptionSearch (LH_TitleDesc), the first categoryId (_sacat),
+// MinPrice and MaxPrice (_udlo, _udhi, in the site currency), Condition (LH_ItemCondition),
+// ListingType (LH_Auction, LH_BIN, LH_CAds), FreeShippingOnly (LH_FS), LocatedIn with the home country
+// of the site only (LH_PrefLoc), buyerPostalCode (_stpos), MaxDistance (_sadis)
+// and sortOrder BestMatch, EndTimeSoonest, StartTimeNewest, PricePlusShippingLowest,
+// PricePlusShippingHighest and DistanceNearest (_sop).
+//
+// Other fields, item filters and sort orders have no website equivalent and are reported as unmapped.
+func (in webSearchInput) webSearch() (WebSearch, error) {
+	domain, ok := siteDomains[in.globalID]
+	if !ok {
+		return WebSearch{}, fmt.Errorf("no website for GlobalID %q", in.globalID)
+	}
+	q := url.Values{}
+	var unmapped []string
+
+	if in.keywords != "" {
+		q.Set("_nkw", in.keywords)
+	}
+	if in.descriptionSearch {
+		q.Set("LH_TitleDesc", "1")
+	}
+	if len(in.categories) > 0 {
+		q.Set("_sacat", in.categories[0])
+		if len(in.categories) > 1 {
+			unmapped = append(unmapped, "categoryId")
+		}
+	}
+	if len(in.aspectFilter) > 0 {
+		unmapped = append(unmapped, "aspectFilter")
+	}
+	if postalCode := in.standard.BuyerPostalCode; postalCode != "" {
+		q.Set("_stpos", postalCode)
+	}
+	if in.standard.SortOrder != "" {
+		if sop, ok := webSortOrders[SortOrderParameter(in.standard.SortOrder)]; ok {
+			q.Set("_sop", sop)
+		} else {
+			unmapped = append(unmapped, "sortOrder")
+		}
+	}
+
+	for _, f := range in.itemFilter.ItemFilters() {
+		if len(f.Value) == 0 {
+			continue
+		}
+		mapped := true
+		switch ItemFilterParameter(f.Name) {
+		case ItemFilterMinPrice:
+			q.Set("_udlo", f.Value[0])
+		case ItemFilterMaxPrice:
+			q.Set("_udhi", f.Value[0])
+		case ItemFilterCondition:
+			q.Set("LH_ItemCondition", strings.Join(f.Value, "|"))
+		case ItemFilterFreeShippingOnly:
+			if f.Value[0] == "true" {
+				q.Set("LH_FS", "1")
+			}
+		case ItemFilterMaxDistance:
+			q.Set("_sadis", f.Value[0])
+		case ItemFilterLocatedIn:
+			if len(f.Value) == 1 && f.Value[0] == siteCountries[in.globalID] {
+				q.Set("LH_PrefLoc", "1")
+			} else {
+				mapped = false
+			}
+		case ItemFilterListingType:
+			mapped = webListingTypes(q, f.Value)
+		default:
+			mapped = false
+		}
+		if !mapped {
+			unmapped = append(unmapped, f.Name)
+		}
+	}
+	sort.Strings(unmapped)
+
+	return WebSearch{
+		URL: &url.URL{
+			Scheme:   "https",
+			Host:     domain,
+			Path:     "/sch/i.html",
+			RawQuery: q.Encode(),
+		},
+		Unmapped: unmapped,
+	}, nil
+}
+
+// webListingTypes sets website parameters of listing types. It returns false if any listing type has no equivalent.
+func webListingTypes(q url.Values, values []string) bool {
+	mapped := true
+	for _, v := range values {
+		switch ItemFilterListingTypeOption(v) {
+		case ListingTypeAuction:
+			q.Set("LH_Auction", "1")
+		case ListingTypeAuctionWithBIN:
+			q.Set("LH_Auction", "1")
+			q.Set("LH_BIN", "1")
+		case ListingTypeFixedPrice, ListingTypeStoreInventory:
+			q.Set("LH_BIN", "1")
+		case ListingTypeClassified:
+			q.Set("LH_CAds", "1")
+		case ListingTypeAll:
+		default:
+			mapped = false
+		}
+	}
+	return mapped
+}
+
+/*
+==============================================================================
+*/
+
+// WebSearch builds eBay website search URL equivalent to AdvancedRequest for the site of the request.
+// No call is made. Fields without website equivalent are listed in WebSearch.Unmapped.
+func (sr *AdvancedRequest) WebSearch() (WebSearch, error) {
+	return webSearchInput{
+		globalID:          sr.globalID(),
+		keywords:          sr.Keywords,
+		descriptionSearch: sr.DescriptionSearch,
+		categories:        sr.CategoryID,
+		aspectFilter:      sr.AspectFilter,
+		itemFilter:        &sr.RequestItemFilter,
+		standard:          &sr.RequestStandard,
+	}.webSearch()
+}
+
+// WebSearch builds eBay website search URL equivalent to ByKeywordsRequest (see AdvancedRequest.WebSearch)
+func (sr *ByKeywordsRequest) WebSearch() (WebSearch, error) {
+	return webSearchInput{
+		globalID:          sr.globalID(),
+		keywords:          sr.Keywords,
+		descriptionSearch: sr.DescriptionSearch,
+		aspectFilter:      sr.AspectFilter,
+		itemFilter:        &sr.RequestItemFilter,
+		standard:          &sr.RequestStandard,
+	}.webSearch()
+}
+
+// WebSearch builds eBay website search URL equivalent to ByCategoryRequest (see AdvancedRequest.WebSearch)
+func (sr *ByCategoryRequest) WebSearch() (WebSearch, error) {
+	return webSearchInput{
+		globalID:     sr.globalID(),
+		categories:   sr.CategoryID,
+		aspectFilter: sr.AspectFilter,
+		itemFilter:   &sr.RequestItemFilter,
+		standard:     &sr.RequestStandard,
+	}.webSearch()
+}
+
+/*
+==============================================================================
+*/
+
+// ParseWebSearchURL creates AdvancedRequest of the service from eBay website search URL
+// (e.g. https://www.ebay.com/sch/i.html?_nkw=harry+potter&LH_BIN=1).
+// The site is detected by the domain and set as GlobalID of the request.
+// It reads the parameters listed in webSearch (and _pgn, _ipg for pagination) and the category of
+// /sch/<name>/<categoryId>/i.html paths. Parameters which can't be mapped are returned sorted by name.
+// Only the first value of repeated parameters is read, so repeated parameters are returned too.
+func (s *Service) ParseWebSearchURL(rawURL string) (*AdvancedRequest, []string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse URL: %w", err)
+	}
+	globalID, ok := siteByHost(u.Hostname())
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown eBay site %q", u.Host)
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if segments[0] != "sch" {
+		return nil, nil, fmt.Errorf("%q isn't eBay search URL", rawURL)
+	}
+
+	req := s.NewAdvancedRequest()
+	req.WithGlobalID(globalID)
+	// /sch/<category name>/<category ID>/i.html
+	if len(segments) == 4 {
+		req.WithCategoryID(segments[2])
+	}
+
+	var unmapped []string
+	q := u.Query()
+	var listingTypes []ItemFilterListingTypeOption
+	for name, values := range q {
+		if !webParam(req, globalID, name, values[0], &listingTypes) || len(values) > 1 {
+			unmapped = append(unmapped, name)
+		}
+	}
+	if len(listingTypes) > 0 {
+		sort.Slice(listingTypes, func(i, j int) bool { return listingTypes[i] < listingTypes[j] })
+		req.WithItemFilterListingType(listingTypes...)
+	}
+	sort.Strings(unmapped)
+	return req, unmapped, nil
+}
+
+// siteByHost detects the site by website host. www. and m. prefixes are ignored.
+func siteByHost(host string) (GlobalID, bool) {
+	host = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(host), "www."), "m.")
+	for globalID, domain := range siteDomains {
+		if globalID != GlobalIDEbayMOTOR && strings.TrimPrefix(domain, "www.") == host {
+			return globalID, true
+		}
+	}
+	return "", false
+}
+
+// webParam sets website parameter to the request. It returns false if the parameter can't be mapped.
+// Listing types are collected into listingTypes.
+func webParam(req *AdvancedRequest, globalID GlobalID, name, value string, listingTypes *[]ItemFilterListingTypeOption) bool {
+	switch name {
+	case "_nkw":
+		req.WithKeywords(value)
+	case "_sacat":
+		// the category can be in the path too
+		if value != "0" && !slices.Contains(req.CategoryID, value) {
+			req.WithCategoryID(value)
+		}
+	case "_udlo", "_udhi":
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		if name == "_udlo" {
+			req.WithItemFilterMinPrice(price)
+		} else {
+			req.WithItemFilterMaxPrice(price)
+		}
+	case "LH_ItemCondition":
+		var conditions []ItemFilterConditionOption
+		for _, c := range strings.FieldsFunc(value, func(r rune) bool { return r == '|' || r == ',' }) {
+			conditions = append(conditions, ItemFilterConditionOption(c))
+		}
+		req.WithItemFilterCondition(conditions...)
+	case "LH_Auction", "LH_BIN", "LH_CAds":
+		if value == "1" {
+			*listingTypes = append(*listingTypes, webListingParams[name])
+		}
+	case "LH_FS":
+		if value == "1" {
+			req.WithItemFilterFreeShippingOnly(true)
+		}
+	case "LH_TitleDesc":
+		req.WithDescriptionSearch(value == "1")
+	case "LH_PrefLoc":
+		if value != "1" {
+			return false
+		}
+		req.WithItemFilterLocatedIn(siteCountries[globalID])
+	case "_stpos":
+		req.WithBuyerPostalCode(value)
+	case "_sadis":
+		distance, err := strconv.Atoi(value)
+		if err != nil {
+			return false
+		}
+		req.WithItemFilterMaxDistance(distance)
+	case "_sop":
+		for order, sop := range webSortOrders {
+			if sop == value {
+				req.WithSortOrder(order)
+				return true
+			}
+		}
+		return false
+	case "_pgn", "_ipg":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return false
+		}
+		if name == "_pgn" {
+			req.WithPageNumber(n)
+		} else {
+			req.WithPageLimit(n)
+		}
+	default:
+		return false
+	}
+	return true
+}
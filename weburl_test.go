@@ -0,0 +1,114 @@
+package finding
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdvancedRequest_WebSearch(t *testing.T) {
+	r := NewService("").NewAdvancedRequest()
+	r.WithKeywords("harry potter")
+	r.WithCategoriesID("267", "1")
+	r.WithItemFilterMinPrice(5)
+	r.WithItemFilterMaxPrice(50)
+	r.WithItemFilterCondition(ConditionNew, ConditionUsed)
+	r.WithItemFilterListingType(ListingTypeFixedPrice)
+	r.WithItemFilterFreeShippingOnly(true)
+	r.WithItemFilterLocatedIn("US")
+	r.WithItemFilterSeller("seller")
+	r.WithSortOrder(SortOrderPricePlusShippingLowest)
+
+	ws, err := r.WebSearch()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "https://www.ebay.com/sch/i.html?LH_BIN=1&LH_FS=1&LH_ItemCondition=1000%7C3000&LH_PrefLoc=1"+
+		"&_nkw=harry+potter&_sacat=267&_sop=15&_udhi=50.00&_udlo=5.00", ws.URL.String())
+	assert.Equal(t, []string{"Seller", "categoryId"}, ws.Unmapped)
+}
+
+func TestByCategoryRequest_WebSearch(t *testing.T) {
+	r := NewService("").WithGlobalID(GlobalIDEbayDE).NewByCategoryRequest()
+	r.WithCategoryID("9355")
+	r.WithItemFilterLocatedIn("US")
+	r.WithSortOrder(SortOrderBidCountMost)
+
+	ws, err := r.WebSearch()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "https://www.ebay.de/sch/i.html?_sacat=9355", ws.URL.String())
+	assert.Equal(t, []string{"LocatedIn", "sortOrder"}, ws.Unmapped)
+}
+
+func TestByKeywordsRequest_WebSearchUnknownSite(t *testing.T) {
+	r := NewService("").WithGlobalID("EBAY-XX").NewByKeywordsRequest()
+	_, err := r.WebSearch()
+	assert.EqualError(t, err, `no website for GlobalID "EBAY-XX"`)
+}
+
+func TestService_ParseWebSearchURL(t *testing.T) {
+	s := NewService("")
+	r, unmapped, err := s.ParseWebSearchURL("https://www.ebay.co.uk/sch/i.html?_nkw=harry+potter&_sacat=267" +
+		"&_udlo=5&_udhi=50.5&LH_ItemCondition=1000%7C3000&LH_BIN=1&LH_Auction=1&LH_FS=1&LH_PrefLoc=1" +
+		"&_sop=15&_pgn=2&_from=R40&_trksid=p2380057")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"_from", "_trksid"}, unmapped)
+	assert.Equal(t, GlobalIDEbayGB, r.globalID())
+	assert.Equal(t, "harry potter", r.Keywords)
+	assert.Equal(t, []string{"267"}, r.CategoryID)
+	assert.Equal(t, string(SortOrderPricePlusShippingLowest), r.SortOrder)
+	assert.Equal(t, 2, r.PaginationInput.PageNumber)
+	assert.Equal(t, []ServiceItemFilter{
+		{Name: string(ItemFilterCondition), Value: []string{string(ConditionNew), string(ConditionUsed)}},
+		{Name: string(ItemFilterFreeShippingOnly), Value: []string{"true"}},
+		{Name: string(ItemFilterListingType), Value: []string{string(ListingTypeAuction), string(ListingTypeFixedPrice)}},
+		{Name: string(ItemFilterLocatedIn), Value: []string{"GB"}},
+		{Name: string(ItemFilterMaxPrice), Value: []string{"50.50"}},
+		{Name: string(ItemFilterMinPrice), Value: []string{"5.00"}},
+	}, r.ItemFilters())
+
+	// the same search as built by WebSearch
+	r.WithPageNumber(1)
+	ws, err := r.WebSearch()
+	assert.NoError(t, err)
+	assert.Empty(t, ws.Unmapped)
+	back, unmapped, err := s.ParseWebSearchURL(ws.URL.String())
+	assert.NoError(t, err)
+	assert.Empty(t, unmapped)
+	assert.Equal(t, r.ItemFilters(), back.ItemFilters())
+}
+
+func TestService_ParseWebSearchURLCategoryPath(t *testing.T) {
+	r, unmapped, err := NewService("").ParseWebSearchURL("https://m.ebay.de/sch/Notebooks/175672/i.html?_sadis=x&_stpos=10115")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"_sadis"}, unmapped)
+	assert.Equal(t, GlobalIDEbayDE, r.globalID())
+	assert.Equal(t, []string{"175672"}, r.CategoryID)
+	assert.Equal(t, "10115", r.BuyerPostalCode)
+}
+
+func TestService_ParseWebSearchURLErrors(t *testing.T) {
+	s := NewService("")
+	_, _, err := s.ParseWebSearchURL("https://www.example.com/sch/i.html?_nkw=a")
+	assert.EqualError(t, err, `unknown eBay site "www.example.com"`)
+	_, _, err = s.ParseWebSearchURL("https://www.ebay.com/itm/123")
+	assert.EqualError(t, err, `"https://www.ebay.com/itm/123" isn't eBay search URL`)
+	_, _, err = s.ParseWebSearchURL("%")
+	assert.Error(t, err)
+}
+
+func TestService_ParseWebSearchURLRepeated(t *testing.T) {
+	r, unmapped, err := NewService("").ParseWebSearchURL(
+		"https://www.ebay.com/sch/Books/267/i.html?_sacat=267&_nkw=harry&_nkw=potter&LH_BIN=1&LH_BIN=0&_foo=1")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"LH_BIN", "_foo", "_nkw"}, unmapped)
+	assert.Equal(t, []string{"267"}, r.CategoryID)
+	assert.Equal(t, "harry", r.Keywords)
+}